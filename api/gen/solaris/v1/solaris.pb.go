@@ -21,6 +21,57 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// LogsSortBy defines the Log field the logs are sorted by. The logs with the same field value are
+// sorted by their IDs.
+type LogsSortBy int32
+
+const (
+	LogsSortBy_LOGS_SORT_BY_ID         LogsSortBy = 0
+	LogsSortBy_LOGS_SORT_BY_CREATED_AT LogsSortBy = 1
+	LogsSortBy_LOGS_SORT_BY_UPDATED_AT LogsSortBy = 2
+)
+
+// Enum value maps for LogsSortBy.
+var (
+	LogsSortBy_name = map[int32]string{
+		0: "LOGS_SORT_BY_ID",
+		1: "LOGS_SORT_BY_CREATED_AT",
+		2: "LOGS_SORT_BY_UPDATED_AT",
+	}
+	LogsSortBy_value = map[string]int32{
+		"LOGS_SORT_BY_ID":         0,
+		"LOGS_SORT_BY_CREATED_AT": 1,
+		"LOGS_SORT_BY_UPDATED_AT": 2,
+	}
+)
+
+func (x LogsSortBy) Enum() *LogsSortBy {
+	p := new(LogsSortBy)
+	*p = x
+	return p
+}
+
+func (x LogsSortBy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LogsSortBy) Descriptor() protoreflect.EnumDescriptor {
+	return file_solaris_proto_enumTypes[0].Descriptor()
+}
+
+func (LogsSortBy) Type() protoreflect.EnumType {
+	return &file_solaris_proto_enumTypes[0]
+}
+
+func (x LogsSortBy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LogsSortBy.Descriptor instead.
+func (LogsSortBy) EnumDescriptor() ([]byte, []int) {
+	return file_solaris_proto_rawDescGZIP(), []int{0}
+}
+
 // Record represents one record of a log
 type Record struct {
 	state         protoimpl.MessageState
@@ -114,6 +165,11 @@ type Log struct {
 	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
 	// records is the number of records in the log
 	Records int64 `protobuf:"varint,5,opt,name=records,proto3" json:"records,omitempty"`
+	// retention is the log records retention policy. If it is not set, the server default policy is applied.
+	Retention *Retention `protobuf:"bytes,6,opt,name=retention,proto3" json:"retention,omitempty"`
+	// version is increased with every log update. If it is provided in the UpdateLog request, the log is updated
+	// only if its current version is the same, so the concurrent updates are not lost.
+	Version int64 `protobuf:"varint,7,opt,name=version,proto3" json:"version,omitempty"`
 }
 
 func (x *Log) Reset() {
@@ -183,20 +239,38 @@ func (x *Log) GetRecords() int64 {
 	return 0
 }
 
-// AppendRecordsRequest describes the parameters for AppendRecords() call
-type AppendRecordsRequest struct {
+func (x *Log) GetRetention() *Retention {
+	if x != nil {
+		return x.Retention
+	}
+	return nil
+}
+
+func (x *Log) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// Retention defines the log records retention policy. The records exceeding the policy limits are removed
+// in background by whole chunks, so a log may keep some records over the limits for a while. The zero value
+// of a limit means there is no the limit.
+type Retention struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// logID is where the new records will be added
-	LogID string `protobuf:"bytes,1,opt,name=logID,proto3" json:"logID,omitempty"`
-	// records the list of records to be added
-	Records []*Record `protobuf:"bytes,2,rep,name=records,proto3" json:"records,omitempty"`
+	// maxAgeSeconds defines how long the records are kept
+	MaxAgeSeconds int64 `protobuf:"varint,1,opt,name=maxAgeSeconds,proto3" json:"maxAgeSeconds,omitempty"`
+	// maxRecords defines the maximum number of records kept in the log
+	MaxRecords int64 `protobuf:"varint,2,opt,name=maxRecords,proto3" json:"maxRecords,omitempty"`
+	// maxSize defines the maximum cumulative payload size (in bytes) of the records kept in the log
+	MaxSize int64 `protobuf:"varint,3,opt,name=maxSize,proto3" json:"maxSize,omitempty"`
 }
 
-func (x *AppendRecordsRequest) Reset() {
-	*x = AppendRecordsRequest{}
+func (x *Retention) Reset() {
+	*x = Retention{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_solaris_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -204,13 +278,13 @@ func (x *AppendRecordsRequest) Reset() {
 	}
 }
 
-func (x *AppendRecordsRequest) String() string {
+func (x *Retention) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AppendRecordsRequest) ProtoMessage() {}
+func (*Retention) ProtoMessage() {}
 
-func (x *AppendRecordsRequest) ProtoReflect() protoreflect.Message {
+func (x *Retention) ProtoReflect() protoreflect.Message {
 	mi := &file_solaris_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -222,36 +296,57 @@ func (x *AppendRecordsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AppendRecordsRequest.ProtoReflect.Descriptor instead.
-func (*AppendRecordsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use Retention.ProtoReflect.Descriptor instead.
+func (*Retention) Descriptor() ([]byte, []int) {
 	return file_solaris_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *AppendRecordsRequest) GetLogID() string {
+func (x *Retention) GetMaxAgeSeconds() int64 {
 	if x != nil {
-		return x.LogID
+		return x.MaxAgeSeconds
 	}
-	return ""
+	return 0
 }
 
-func (x *AppendRecordsRequest) GetRecords() []*Record {
+func (x *Retention) GetMaxRecords() int64 {
 	if x != nil {
-		return x.Records
+		return x.MaxRecords
 	}
-	return nil
+	return 0
 }
 
-// AppendRecordsResult contains the number or records added to the log
-type AppendRecordsResult struct {
+func (x *Retention) GetMaxSize() int64 {
+	if x != nil {
+		return x.MaxSize
+	}
+	return 0
+}
+
+// AppendRecordsRequest describes the parameters for AppendRecords() call
+type AppendRecordsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Added int64 `protobuf:"varint,1,opt,name=added,proto3" json:"added,omitempty"`
+	// logID is where the new records will be added
+	LogID string `protobuf:"bytes,1,opt,name=logID,proto3" json:"logID,omitempty"`
+	// records the list of records to be added
+	Records []*Record `protobuf:"bytes,2,rep,name=records,proto3" json:"records,omitempty"`
+	// dedupKey is an optional client-supplied idempotency key of the request. If the request with the same
+	// dedupKey was already applied to the log within the server deduplication window, the records are not
+	// written again, and the result of the previous request is returned instead.
+	DedupKey string `protobuf:"bytes,3,opt,name=dedupKey,proto3" json:"dedupKey,omitempty"`
+	// expectedLastRecordID makes the append conditional: the records are added only if the last record ID
+	// of the log is the expected one (empty value expects the log has no records). Otherwise, the request
+	// fails with the FAILED_PRECONDITION error.
+	ExpectedLastRecordID *string `protobuf:"bytes,4,opt,name=expectedLastRecordID,proto3,oneof" json:"expectedLastRecordID,omitempty"`
+	// expectedRecordsCount makes the append conditional: the records are added only if the number of records
+	// in the log is the expected one. Otherwise, the request fails with the FAILED_PRECONDITION error.
+	ExpectedRecordsCount *int64 `protobuf:"varint,5,opt,name=expectedRecordsCount,proto3,oneof" json:"expectedRecordsCount,omitempty"`
 }
 
-func (x *AppendRecordsResult) Reset() {
-	*x = AppendRecordsResult{}
+func (x *AppendRecordsRequest) Reset() {
+	*x = AppendRecordsRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_solaris_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -259,13 +354,13 @@ func (x *AppendRecordsResult) Reset() {
 	}
 }
 
-func (x *AppendRecordsResult) String() string {
+func (x *AppendRecordsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AppendRecordsResult) ProtoMessage() {}
+func (*AppendRecordsRequest) ProtoMessage() {}
 
-func (x *AppendRecordsResult) ProtoReflect() protoreflect.Message {
+func (x *AppendRecordsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_solaris_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -277,34 +372,67 @@ func (x *AppendRecordsResult) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AppendRecordsResult.ProtoReflect.Descriptor instead.
-func (*AppendRecordsResult) Descriptor() ([]byte, []int) {
+// Deprecated: Use AppendRecordsRequest.ProtoReflect.Descriptor instead.
+func (*AppendRecordsRequest) Descriptor() ([]byte, []int) {
 	return file_solaris_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *AppendRecordsResult) GetAdded() int64 {
+func (x *AppendRecordsRequest) GetLogID() string {
 	if x != nil {
-		return x.Added
+		return x.LogID
+	}
+	return ""
+}
+
+func (x *AppendRecordsRequest) GetRecords() []*Record {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+func (x *AppendRecordsRequest) GetDedupKey() string {
+	if x != nil {
+		return x.DedupKey
+	}
+	return ""
+}
+
+func (x *AppendRecordsRequest) GetExpectedLastRecordID() string {
+	if x != nil && x.ExpectedLastRecordID != nil {
+		return *x.ExpectedLastRecordID
+	}
+	return ""
+}
+
+func (x *AppendRecordsRequest) GetExpectedRecordsCount() int64 {
+	if x != nil && x.ExpectedRecordsCount != nil {
+		return *x.ExpectedRecordsCount
 	}
 	return 0
 }
 
-// QueryLogsRequest allows to read multiple Log objects per one request
-type QueryLogsRequest struct {
+// AppendRecordsResult contains the number or records added to the log
+type AppendRecordsResult struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// condition describes the log filter condition
-	Condition string `protobuf:"bytes,1,opt,name=condition,proto3" json:"condition,omitempty"`
-	// pageID is provided for paginated results
-	PageID string `protobuf:"bytes,2,opt,name=pageID,proto3" json:"pageID,omitempty"`
-	// limit contains tha maximum number of Log objects in the result
-	Limit int64 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Added int64 `protobuf:"varint,1,opt,name=added,proto3" json:"added,omitempty"`
+	// firstID is the ID of the first added record
+	FirstID string `protobuf:"bytes,2,opt,name=firstID,proto3" json:"firstID,omitempty"`
+	// lastID is the ID of the last added record. The records added by one request get the IDs
+	// in the range [firstID..lastID] in the order they are in the request, so if only one record
+	// is added, the firstID and the lastID are the record ID.
+	LastID string `protobuf:"bytes,3,opt,name=lastID,proto3" json:"lastID,omitempty"`
+	// firstCreatedAt is the createdAt timestamp of the first added record
+	FirstCreatedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=firstCreatedAt,proto3" json:"firstCreatedAt,omitempty"`
+	// lastCreatedAt is the createdAt timestamp of the last added record
+	LastCreatedAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=lastCreatedAt,proto3" json:"lastCreatedAt,omitempty"`
 }
 
-func (x *QueryLogsRequest) Reset() {
-	*x = QueryLogsRequest{}
+func (x *AppendRecordsResult) Reset() {
+	*x = AppendRecordsResult{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_solaris_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -312,13 +440,13 @@ func (x *QueryLogsRequest) Reset() {
 	}
 }
 
-func (x *QueryLogsRequest) String() string {
+func (x *AppendRecordsResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*QueryLogsRequest) ProtoMessage() {}
+func (*AppendRecordsResult) ProtoMessage() {}
 
-func (x *QueryLogsRequest) ProtoReflect() protoreflect.Message {
+func (x *AppendRecordsResult) ProtoReflect() protoreflect.Message {
 	mi := &file_solaris_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -330,48 +458,62 @@ func (x *QueryLogsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use QueryLogsRequest.ProtoReflect.Descriptor instead.
-func (*QueryLogsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use AppendRecordsResult.ProtoReflect.Descriptor instead.
+func (*AppendRecordsResult) Descriptor() ([]byte, []int) {
 	return file_solaris_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *QueryLogsRequest) GetCondition() string {
+func (x *AppendRecordsResult) GetAdded() int64 {
 	if x != nil {
-		return x.Condition
+		return x.Added
+	}
+	return 0
+}
+
+func (x *AppendRecordsResult) GetFirstID() string {
+	if x != nil {
+		return x.FirstID
 	}
 	return ""
 }
 
-func (x *QueryLogsRequest) GetPageID() string {
+func (x *AppendRecordsResult) GetLastID() string {
 	if x != nil {
-		return x.PageID
+		return x.LastID
 	}
 	return ""
 }
 
-func (x *QueryLogsRequest) GetLimit() int64 {
+func (x *AppendRecordsResult) GetFirstCreatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Limit
+		return x.FirstCreatedAt
 	}
-	return 0
+	return nil
 }
 
-// QueryLogsResult describes the response for QueryLogsRequest
-type QueryLogsResult struct {
+func (x *AppendRecordsResult) GetLastCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastCreatedAt
+	}
+	return nil
+}
+
+// AppendRecordsAck acknowledges one AppendRecordsRequest of the AppendRecordsStream
+type AppendRecordsAck struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// logs is the list of Log objects in the result
-	Logs []*Log `protobuf:"bytes,1,rep,name=logs,proto3" json:"logs,omitempty"`
-	// nextPageID contains the pageID for reading next portion of the records if any
-	NextPageID string `protobuf:"bytes,2,opt,name=nextPageID,proto3" json:"nextPageID,omitempty"`
-	// total is the number of records matched to the result
-	Total int64 `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	// seqNo is the sequence number of the acknowledged request in the stream, starting from 1
+	SeqNo int64 `protobuf:"varint,1,opt,name=seqNo,proto3" json:"seqNo,omitempty"`
+	// logID is the log where the records were added
+	LogID string `protobuf:"bytes,2,opt,name=logID,proto3" json:"logID,omitempty"`
+	// result is the append result for the request
+	Result *AppendRecordsResult `protobuf:"bytes,3,opt,name=result,proto3" json:"result,omitempty"`
 }
 
-func (x *QueryLogsResult) Reset() {
-	*x = QueryLogsResult{}
+func (x *AppendRecordsAck) Reset() {
+	*x = AppendRecordsAck{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_solaris_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -379,13 +521,13 @@ func (x *QueryLogsResult) Reset() {
 	}
 }
 
-func (x *QueryLogsResult) String() string {
+func (x *AppendRecordsAck) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*QueryLogsResult) ProtoMessage() {}
+func (*AppendRecordsAck) ProtoMessage() {}
 
-func (x *QueryLogsResult) ProtoReflect() protoreflect.Message {
+func (x *AppendRecordsAck) ProtoReflect() protoreflect.Message {
 	mi := &file_solaris_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -397,43 +539,43 @@ func (x *QueryLogsResult) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use QueryLogsResult.ProtoReflect.Descriptor instead.
-func (*QueryLogsResult) Descriptor() ([]byte, []int) {
+// Deprecated: Use AppendRecordsAck.ProtoReflect.Descriptor instead.
+func (*AppendRecordsAck) Descriptor() ([]byte, []int) {
 	return file_solaris_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *QueryLogsResult) GetLogs() []*Log {
+func (x *AppendRecordsAck) GetSeqNo() int64 {
 	if x != nil {
-		return x.Logs
+		return x.SeqNo
 	}
-	return nil
+	return 0
 }
 
-func (x *QueryLogsResult) GetNextPageID() string {
+func (x *AppendRecordsAck) GetLogID() string {
 	if x != nil {
-		return x.NextPageID
+		return x.LogID
 	}
 	return ""
 }
 
-func (x *QueryLogsResult) GetTotal() int64 {
+func (x *AppendRecordsAck) GetResult() *AppendRecordsResult {
 	if x != nil {
-		return x.Total
+		return x.Result
 	}
-	return 0
+	return nil
 }
 
-// DeleteLogsRequest specifies the condition for the deleted logs
-type DeleteLogsRequest struct {
+// AppendRecordsBatchRequest contains the append requests for several logs
+type AppendRecordsBatchRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Condition string `protobuf:"bytes,1,opt,name=condition,proto3" json:"condition,omitempty"`
+	Requests []*AppendRecordsRequest `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
 }
 
-func (x *DeleteLogsRequest) Reset() {
-	*x = DeleteLogsRequest{}
+func (x *AppendRecordsBatchRequest) Reset() {
+	*x = AppendRecordsBatchRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_solaris_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -441,13 +583,13 @@ func (x *DeleteLogsRequest) Reset() {
 	}
 }
 
-func (x *DeleteLogsRequest) String() string {
+func (x *AppendRecordsBatchRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteLogsRequest) ProtoMessage() {}
+func (*AppendRecordsBatchRequest) ProtoMessage() {}
 
-func (x *DeleteLogsRequest) ProtoReflect() protoreflect.Message {
+func (x *AppendRecordsBatchRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_solaris_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -459,29 +601,29 @@ func (x *DeleteLogsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteLogsRequest.ProtoReflect.Descriptor instead.
-func (*DeleteLogsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use AppendRecordsBatchRequest.ProtoReflect.Descriptor instead.
+func (*AppendRecordsBatchRequest) Descriptor() ([]byte, []int) {
 	return file_solaris_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *DeleteLogsRequest) GetCondition() string {
+func (x *AppendRecordsBatchRequest) GetRequests() []*AppendRecordsRequest {
 	if x != nil {
-		return x.Condition
+		return x.Requests
 	}
-	return ""
+	return nil
 }
 
-// DeleteLogsResult describes the response for DeleteLogsRequest
-type DeleteLogsResult struct {
+// AppendRecordsBatchResult contains the results of AppendRecordsBatchRequest in the order of the requests
+type AppendRecordsBatchResult struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	DeletedIDs []string `protobuf:"bytes,1,rep,name=deletedIDs,proto3" json:"deletedIDs,omitempty"`
+	Results []*AppendRecordsResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
 }
 
-func (x *DeleteLogsResult) Reset() {
-	*x = DeleteLogsResult{}
+func (x *AppendRecordsBatchResult) Reset() {
+	*x = AppendRecordsBatchResult{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_solaris_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -489,13 +631,13 @@ func (x *DeleteLogsResult) Reset() {
 	}
 }
 
-func (x *DeleteLogsResult) String() string {
+func (x *AppendRecordsBatchResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteLogsResult) ProtoMessage() {}
+func (*AppendRecordsBatchResult) ProtoMessage() {}
 
-func (x *DeleteLogsResult) ProtoReflect() protoreflect.Message {
+func (x *AppendRecordsBatchResult) ProtoReflect() protoreflect.Message {
 	mi := &file_solaris_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -507,30 +649,36 @@ func (x *DeleteLogsResult) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteLogsResult.ProtoReflect.Descriptor instead.
-func (*DeleteLogsResult) Descriptor() ([]byte, []int) {
+// Deprecated: Use AppendRecordsBatchResult.ProtoReflect.Descriptor instead.
+func (*AppendRecordsBatchResult) Descriptor() ([]byte, []int) {
 	return file_solaris_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *DeleteLogsResult) GetDeletedIDs() []string {
+func (x *AppendRecordsBatchResult) GetResults() []*AppendRecordsResult {
 	if x != nil {
-		return x.DeletedIDs
+		return x.Results
 	}
 	return nil
 }
 
-// CountResult returns a counted number of an operation
-type CountResult struct {
+// PatchLogTagsRequest describes the changes of the log tags
+type PatchLogTagsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// total contains the requested number
-	Total int64 `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	// logID is the log identifier
+	LogID string `protobuf:"bytes,1,opt,name=logID,proto3" json:"logID,omitempty"`
+	// set contains the tags to be added or changed
+	Set map[string]string `protobuf:"bytes,2,rep,name=set,proto3" json:"set,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// remove contains the tag names to be removed
+	Remove []string `protobuf:"bytes,3,rep,name=remove,proto3" json:"remove,omitempty"`
+	// version is the log version the patch is applied to. The version is not checked if it is not provided.
+	Version int64 `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
 }
 
-func (x *CountResult) Reset() {
-	*x = CountResult{}
+func (x *PatchLogTagsRequest) Reset() {
+	*x = PatchLogTagsRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_solaris_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -538,13 +686,13 @@ func (x *CountResult) Reset() {
 	}
 }
 
-func (x *CountResult) String() string {
+func (x *PatchLogTagsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CountResult) ProtoMessage() {}
+func (*PatchLogTagsRequest) ProtoMessage() {}
 
-func (x *CountResult) ProtoReflect() protoreflect.Message {
+func (x *PatchLogTagsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_solaris_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -556,44 +704,55 @@ func (x *CountResult) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CountResult.ProtoReflect.Descriptor instead.
-func (*CountResult) Descriptor() ([]byte, []int) {
+// Deprecated: Use PatchLogTagsRequest.ProtoReflect.Descriptor instead.
+func (*PatchLogTagsRequest) Descriptor() ([]byte, []int) {
 	return file_solaris_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *CountResult) GetTotal() int64 {
+func (x *PatchLogTagsRequest) GetLogID() string {
 	if x != nil {
-		return x.Total
+		return x.LogID
+	}
+	return ""
+}
+
+func (x *PatchLogTagsRequest) GetSet() map[string]string {
+	if x != nil {
+		return x.Set
+	}
+	return nil
+}
+
+func (x *PatchLogTagsRequest) GetRemove() []string {
+	if x != nil {
+		return x.Remove
+	}
+	return nil
+}
+
+func (x *PatchLogTagsRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
 	}
 	return 0
 }
 
-// QueryRecordsRequest contains arguments for requesting Log(s) records
-type QueryRecordsRequest struct {
+// BulkPatchLogTagsRequest describes the changes of the tags of the logs matching the condition
+type BulkPatchLogTagsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// logsCondition allows to specify the filter condition for selecting logs.
-	LogsCondition string `protobuf:"bytes,1,opt,name=logsCondition,proto3" json:"logsCondition,omitempty"`
-	// condition allows to specify the filter for the records.
-	Condition string `protobuf:"bytes,2,opt,name=condition,proto3" json:"condition,omitempty"`
-	// logIDs allows to specify the list of logs explicitly. If it is provided, then the logsCondition will be ignored.
-	LogIDs []string `protobuf:"bytes,3,rep,name=logIDs,proto3" json:"logIDs,omitempty"`
-	// descending specifies that the result should be sorted in the record ID descending order.
-	Descending bool `protobuf:"varint,4,opt,name=descending,proto3" json:"descending,omitempty"`
-	// startRecordID defines the first record ID the result set may start from. In case of ascending order, the
-	// result will contain records with IDs are equal or GREATER the startRecordID. If the descending order
-	// is true, the result will contain records with IDs are equal or LOWER the startRecordID.
-	// If the startRecordID is empty the result will start from either first or latest record in the log, depending
-	// on the descending flag value.
-	StartRecordID string `protobuf:"bytes,5,opt,name=startRecordID,proto3" json:"startRecordID,omitempty"`
-	// limit contains the number of records to be returned
-	Limit int64 `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`
+	// condition describes the filter condition for the logs
+	Condition string `protobuf:"bytes,1,opt,name=condition,proto3" json:"condition,omitempty"`
+	// set contains the tags to be added or changed
+	Set map[string]string `protobuf:"bytes,2,rep,name=set,proto3" json:"set,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// remove contains the tag names to be removed
+	Remove []string `protobuf:"bytes,3,rep,name=remove,proto3" json:"remove,omitempty"`
 }
 
-func (x *QueryRecordsRequest) Reset() {
-	*x = QueryRecordsRequest{}
+func (x *BulkPatchLogTagsRequest) Reset() {
+	*x = BulkPatchLogTagsRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_solaris_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -601,13 +760,13 @@ func (x *QueryRecordsRequest) Reset() {
 	}
 }
 
-func (x *QueryRecordsRequest) String() string {
+func (x *BulkPatchLogTagsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*QueryRecordsRequest) ProtoMessage() {}
+func (*BulkPatchLogTagsRequest) ProtoMessage() {}
 
-func (x *QueryRecordsRequest) ProtoReflect() protoreflect.Message {
+func (x *BulkPatchLogTagsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_solaris_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -619,82 +778,1210 @@ func (x *QueryRecordsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use QueryRecordsRequest.ProtoReflect.Descriptor instead.
+// Deprecated: Use BulkPatchLogTagsRequest.ProtoReflect.Descriptor instead.
+func (*BulkPatchLogTagsRequest) Descriptor() ([]byte, []int) {
+	return file_solaris_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *BulkPatchLogTagsRequest) GetCondition() string {
+	if x != nil {
+		return x.Condition
+	}
+	return ""
+}
+
+func (x *BulkPatchLogTagsRequest) GetSet() map[string]string {
+	if x != nil {
+		return x.Set
+	}
+	return nil
+}
+
+func (x *BulkPatchLogTagsRequest) GetRemove() []string {
+	if x != nil {
+		return x.Remove
+	}
+	return nil
+}
+
+// BulkPatchLogTagsResult describes the response for BulkPatchLogTagsRequest
+type BulkPatchLogTagsResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PatchedIDs []string `protobuf:"bytes,1,rep,name=patchedIDs,proto3" json:"patchedIDs,omitempty"`
+}
+
+func (x *BulkPatchLogTagsResult) Reset() {
+	*x = BulkPatchLogTagsResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_solaris_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BulkPatchLogTagsResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkPatchLogTagsResult) ProtoMessage() {}
+
+func (x *BulkPatchLogTagsResult) ProtoReflect() protoreflect.Message {
+	mi := &file_solaris_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkPatchLogTagsResult.ProtoReflect.Descriptor instead.
+func (*BulkPatchLogTagsResult) Descriptor() ([]byte, []int) {
+	return file_solaris_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *BulkPatchLogTagsResult) GetPatchedIDs() []string {
+	if x != nil {
+		return x.PatchedIDs
+	}
+	return nil
+}
+
+// QueryLogsRequest allows to read multiple Log objects per one request
+type QueryLogsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// condition describes the log filter condition. If it is empty, all the logs are selected.
+	Condition string `protobuf:"bytes,1,opt,name=condition,proto3" json:"condition,omitempty"`
+	// pageID is provided for paginated results
+	PageID string `protobuf:"bytes,2,opt,name=pageID,proto3" json:"pageID,omitempty"`
+	// limit contains tha maximum number of Log objects in the result
+	Limit int64 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	// sortBy defines the order of the logs in the result, the logs are sorted by their IDs by default
+	SortBy LogsSortBy `protobuf:"varint,4,opt,name=sortBy,proto3,enum=solaris.v1.LogsSortBy" json:"sortBy,omitempty"`
+	// descending specifies that the result should be sorted in the descending order
+	Descending bool `protobuf:"varint,5,opt,name=descending,proto3" json:"descending,omitempty"`
+	// skipTotal allows not to count the total number of the logs matching the condition. Counting it requires
+	// all the matching logs to be checked, so it may be skipped when not needed, e.g. for the next pages.
+	SkipTotal bool `protobuf:"varint,6,opt,name=skipTotal,proto3" json:"skipTotal,omitempty"`
+}
+
+func (x *QueryLogsRequest) Reset() {
+	*x = QueryLogsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_solaris_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueryLogsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryLogsRequest) ProtoMessage() {}
+
+func (x *QueryLogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_solaris_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryLogsRequest.ProtoReflect.Descriptor instead.
+func (*QueryLogsRequest) Descriptor() ([]byte, []int) {
+	return file_solaris_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *QueryLogsRequest) GetCondition() string {
+	if x != nil {
+		return x.Condition
+	}
+	return ""
+}
+
+func (x *QueryLogsRequest) GetPageID() string {
+	if x != nil {
+		return x.PageID
+	}
+	return ""
+}
+
+func (x *QueryLogsRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *QueryLogsRequest) GetSortBy() LogsSortBy {
+	if x != nil {
+		return x.SortBy
+	}
+	return LogsSortBy_LOGS_SORT_BY_ID
+}
+
+func (x *QueryLogsRequest) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+func (x *QueryLogsRequest) GetSkipTotal() bool {
+	if x != nil {
+		return x.SkipTotal
+	}
+	return false
+}
+
+// QueryLogsResult describes the response for QueryLogsRequest
+type QueryLogsResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// logs is the list of Log objects in the result
+	Logs []*Log `protobuf:"bytes,1,rep,name=logs,proto3" json:"logs,omitempty"`
+	// nextPageID contains the pageID for reading next portion of the records if any
+	NextPageID string `protobuf:"bytes,2,opt,name=nextPageID,proto3" json:"nextPageID,omitempty"`
+	// total is the number of logs matching the condition, it is not provided if skipTotal is requested
+	Total int64 `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+}
+
+func (x *QueryLogsResult) Reset() {
+	*x = QueryLogsResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_solaris_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueryLogsResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryLogsResult) ProtoMessage() {}
+
+func (x *QueryLogsResult) ProtoReflect() protoreflect.Message {
+	mi := &file_solaris_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryLogsResult.ProtoReflect.Descriptor instead.
+func (*QueryLogsResult) Descriptor() ([]byte, []int) {
+	return file_solaris_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *QueryLogsResult) GetLogs() []*Log {
+	if x != nil {
+		return x.Logs
+	}
+	return nil
+}
+
+func (x *QueryLogsResult) GetNextPageID() string {
+	if x != nil {
+		return x.NextPageID
+	}
+	return ""
+}
+
+func (x *QueryLogsResult) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+// GetTagFacetsRequest specifies the logs and the tag the facets are requested for
+type GetTagFacetsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// condition describes the log filter condition. If it is empty, all the logs are considered.
+	Condition string `protobuf:"bytes,1,opt,name=condition,proto3" json:"condition,omitempty"`
+	// tag is the tag name the distinct values are requested for. If it is empty, the distinct tag names are requested.
+	Tag string `protobuf:"bytes,2,opt,name=tag,proto3" json:"tag,omitempty"`
+	// limit contains the maximum number of facets in the result, the value 0 means no limit
+	Limit int64 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *GetTagFacetsRequest) Reset() {
+	*x = GetTagFacetsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_solaris_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTagFacetsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTagFacetsRequest) ProtoMessage() {}
+
+func (x *GetTagFacetsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_solaris_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTagFacetsRequest.ProtoReflect.Descriptor instead.
+func (*GetTagFacetsRequest) Descriptor() ([]byte, []int) {
+	return file_solaris_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetTagFacetsRequest) GetCondition() string {
+	if x != nil {
+		return x.Condition
+	}
+	return ""
+}
+
+func (x *GetTagFacetsRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *GetTagFacetsRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// TagFacet describes one distinct tag name or value
+type TagFacet struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// value is the tag name or the tag value
+	Value string `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	// count is the number of logs having the tag name or the tag value
+	Count int64 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *TagFacet) Reset() {
+	*x = TagFacet{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_solaris_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TagFacet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TagFacet) ProtoMessage() {}
+
+func (x *TagFacet) ProtoReflect() protoreflect.Message {
+	mi := &file_solaris_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TagFacet.ProtoReflect.Descriptor instead.
+func (*TagFacet) Descriptor() ([]byte, []int) {
+	return file_solaris_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *TagFacet) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *TagFacet) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// GetTagFacetsResult describes the response for GetTagFacetsRequest. The tags with the empty values are disregarded.
+type GetTagFacetsResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// facets is the list of facets sorted by their values in the ascending order
+	Facets []*TagFacet `protobuf:"bytes,1,rep,name=facets,proto3" json:"facets,omitempty"`
+	// total is the number of the distinct tag names (values) found, it may exceed the number of facets
+	// returned if the limit is applied
+	Total int64 `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+}
+
+func (x *GetTagFacetsResult) Reset() {
+	*x = GetTagFacetsResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_solaris_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTagFacetsResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTagFacetsResult) ProtoMessage() {}
+
+func (x *GetTagFacetsResult) ProtoReflect() protoreflect.Message {
+	mi := &file_solaris_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTagFacetsResult.ProtoReflect.Descriptor instead.
+func (*GetTagFacetsResult) Descriptor() ([]byte, []int) {
+	return file_solaris_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetTagFacetsResult) GetFacets() []*TagFacet {
+	if x != nil {
+		return x.Facets
+	}
+	return nil
+}
+
+func (x *GetTagFacetsResult) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+// DeleteLogsRequest specifies the condition for the deleted logs
+type DeleteLogsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Condition string `protobuf:"bytes,1,opt,name=condition,proto3" json:"condition,omitempty"`
+}
+
+func (x *DeleteLogsRequest) Reset() {
+	*x = DeleteLogsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_solaris_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteLogsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteLogsRequest) ProtoMessage() {}
+
+func (x *DeleteLogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_solaris_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteLogsRequest.ProtoReflect.Descriptor instead.
+func (*DeleteLogsRequest) Descriptor() ([]byte, []int) {
+	return file_solaris_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *DeleteLogsRequest) GetCondition() string {
+	if x != nil {
+		return x.Condition
+	}
+	return ""
+}
+
+// DeleteLogsResult describes the response for DeleteLogsRequest
+type DeleteLogsResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DeletedIDs []string `protobuf:"bytes,1,rep,name=deletedIDs,proto3" json:"deletedIDs,omitempty"`
+}
+
+func (x *DeleteLogsResult) Reset() {
+	*x = DeleteLogsResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_solaris_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteLogsResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteLogsResult) ProtoMessage() {}
+
+func (x *DeleteLogsResult) ProtoReflect() protoreflect.Message {
+	mi := &file_solaris_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteLogsResult.ProtoReflect.Descriptor instead.
+func (*DeleteLogsResult) Descriptor() ([]byte, []int) {
+	return file_solaris_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DeleteLogsResult) GetDeletedIDs() []string {
+	if x != nil {
+		return x.DeletedIDs
+	}
+	return nil
+}
+
+// UndeleteLogsRequest specifies the deleted logs to be restored
+type UndeleteLogsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// condition describes the filter condition for the deleted logs
+	Condition string `protobuf:"bytes,1,opt,name=condition,proto3" json:"condition,omitempty"`
+	// logIDs allows to specify the list of logs explicitly. If it is provided, then the condition will be ignored.
+	LogIDs []string `protobuf:"bytes,2,rep,name=logIDs,proto3" json:"logIDs,omitempty"`
+}
+
+func (x *UndeleteLogsRequest) Reset() {
+	*x = UndeleteLogsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_solaris_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UndeleteLogsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UndeleteLogsRequest) ProtoMessage() {}
+
+func (x *UndeleteLogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_solaris_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UndeleteLogsRequest.ProtoReflect.Descriptor instead.
+func (*UndeleteLogsRequest) Descriptor() ([]byte, []int) {
+	return file_solaris_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *UndeleteLogsRequest) GetCondition() string {
+	if x != nil {
+		return x.Condition
+	}
+	return ""
+}
+
+func (x *UndeleteLogsRequest) GetLogIDs() []string {
+	if x != nil {
+		return x.LogIDs
+	}
+	return nil
+}
+
+// UndeleteLogsResult describes the response for UndeleteLogsRequest
+type UndeleteLogsResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UndeletedIDs []string `protobuf:"bytes,1,rep,name=undeletedIDs,proto3" json:"undeletedIDs,omitempty"`
+}
+
+func (x *UndeleteLogsResult) Reset() {
+	*x = UndeleteLogsResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_solaris_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UndeleteLogsResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UndeleteLogsResult) ProtoMessage() {}
+
+func (x *UndeleteLogsResult) ProtoReflect() protoreflect.Message {
+	mi := &file_solaris_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UndeleteLogsResult.ProtoReflect.Descriptor instead.
+func (*UndeleteLogsResult) Descriptor() ([]byte, []int) {
+	return file_solaris_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *UndeleteLogsResult) GetUndeletedIDs() []string {
+	if x != nil {
+		return x.UndeletedIDs
+	}
+	return nil
+}
+
+// TruncateLogRequest specifies the log and the point the log records are removed before
+type TruncateLogRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// logID is the log identifier
+	LogID string `protobuf:"bytes,1,opt,name=logID,proto3" json:"logID,omitempty"`
+	// beforeRecordID defines the first record ID which is kept in the log, all the records before it are removed
+	BeforeRecordID string `protobuf:"bytes,2,opt,name=beforeRecordID,proto3" json:"beforeRecordID,omitempty"`
+	// beforeTime defines the time point, all the records created before it are removed. The field is ignored if
+	// the beforeRecordID is provided.
+	BeforeTime *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=beforeTime,proto3" json:"beforeTime,omitempty"`
+}
+
+func (x *TruncateLogRequest) Reset() {
+	*x = TruncateLogRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_solaris_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TruncateLogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TruncateLogRequest) ProtoMessage() {}
+
+func (x *TruncateLogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_solaris_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TruncateLogRequest.ProtoReflect.Descriptor instead.
+func (*TruncateLogRequest) Descriptor() ([]byte, []int) {
+	return file_solaris_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *TruncateLogRequest) GetLogID() string {
+	if x != nil {
+		return x.LogID
+	}
+	return ""
+}
+
+func (x *TruncateLogRequest) GetBeforeRecordID() string {
+	if x != nil {
+		return x.BeforeRecordID
+	}
+	return ""
+}
+
+func (x *TruncateLogRequest) GetBeforeTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BeforeTime
+	}
+	return nil
+}
+
+// TruncateLogResult describes the response for TruncateLogRequest
+type TruncateLogResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// removed contains the number of records removed from the log
+	Removed int64 `protobuf:"varint,1,opt,name=removed,proto3" json:"removed,omitempty"`
+}
+
+func (x *TruncateLogResult) Reset() {
+	*x = TruncateLogResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_solaris_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TruncateLogResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TruncateLogResult) ProtoMessage() {}
+
+func (x *TruncateLogResult) ProtoReflect() protoreflect.Message {
+	mi := &file_solaris_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TruncateLogResult.ProtoReflect.Descriptor instead.
+func (*TruncateLogResult) Descriptor() ([]byte, []int) {
+	return file_solaris_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *TruncateLogResult) GetRemoved() int64 {
+	if x != nil {
+		return x.Removed
+	}
+	return 0
+}
+
+// GetLogStatsRequest specifies the log the statistics is requested for
+type GetLogStatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// logID is the log identifier
+	LogID string `protobuf:"bytes,1,opt,name=logID,proto3" json:"logID,omitempty"`
+}
+
+func (x *GetLogStatsRequest) Reset() {
+	*x = GetLogStatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_solaris_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetLogStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLogStatsRequest) ProtoMessage() {}
+
+func (x *GetLogStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_solaris_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLogStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetLogStatsRequest) Descriptor() ([]byte, []int) {
+	return file_solaris_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetLogStatsRequest) GetLogID() string {
+	if x != nil {
+		return x.LogID
+	}
+	return ""
+}
+
+// LogStats contains the log records statistics. The statistics reflects the records visible for the readers,
+// so it takes into account the records removed by the retention policies and the log truncation.
+type LogStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// logID is the log identifier
+	LogID string `protobuf:"bytes,1,opt,name=logID,proto3" json:"logID,omitempty"`
+	// records is the number of records in the log
+	Records int64 `protobuf:"varint,2,opt,name=records,proto3" json:"records,omitempty"`
+	// size is the cumulative payload size (in bytes) of the log records
+	Size int64 `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	// firstRecordID is the ID of the first record of the log, it is empty if the log has no records
+	FirstRecordID string `protobuf:"bytes,4,opt,name=firstRecordID,proto3" json:"firstRecordID,omitempty"`
+	// lastRecordID is the ID of the last record of the log, it is empty if the log has no records
+	LastRecordID string `protobuf:"bytes,5,opt,name=lastRecordID,proto3" json:"lastRecordID,omitempty"`
+	// lastAppendedAt is the time when the last record of the log was appended, it is not set if the log has no records
+	LastAppendedAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=lastAppendedAt,proto3" json:"lastAppendedAt,omitempty"`
+	// chunks is the number of chunks the log records are stored in
+	Chunks int64 `protobuf:"varint,7,opt,name=chunks,proto3" json:"chunks,omitempty"`
+}
+
+func (x *LogStats) Reset() {
+	*x = LogStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_solaris_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LogStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogStats) ProtoMessage() {}
+
+func (x *LogStats) ProtoReflect() protoreflect.Message {
+	mi := &file_solaris_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogStats.ProtoReflect.Descriptor instead.
+func (*LogStats) Descriptor() ([]byte, []int) {
+	return file_solaris_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *LogStats) GetLogID() string {
+	if x != nil {
+		return x.LogID
+	}
+	return ""
+}
+
+func (x *LogStats) GetRecords() int64 {
+	if x != nil {
+		return x.Records
+	}
+	return 0
+}
+
+func (x *LogStats) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *LogStats) GetFirstRecordID() string {
+	if x != nil {
+		return x.FirstRecordID
+	}
+	return ""
+}
+
+func (x *LogStats) GetLastRecordID() string {
+	if x != nil {
+		return x.LastRecordID
+	}
+	return ""
+}
+
+func (x *LogStats) GetLastAppendedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastAppendedAt
+	}
+	return nil
+}
+
+func (x *LogStats) GetChunks() int64 {
+	if x != nil {
+		return x.Chunks
+	}
+	return 0
+}
+
+// CountResult returns a counted number of an operation
+type CountResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// total contains the requested number
+	Total int64 `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+}
+
+func (x *CountResult) Reset() {
+	*x = CountResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_solaris_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CountResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountResult) ProtoMessage() {}
+
+func (x *CountResult) ProtoReflect() protoreflect.Message {
+	mi := &file_solaris_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountResult.ProtoReflect.Descriptor instead.
+func (*CountResult) Descriptor() ([]byte, []int) {
+	return file_solaris_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *CountResult) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+// QueryRecordsRequest contains arguments for requesting Log(s) records
+type QueryRecordsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// logsCondition allows to specify the filter condition for selecting logs.
+	LogsCondition string `protobuf:"bytes,1,opt,name=logsCondition,proto3" json:"logsCondition,omitempty"`
+	// condition allows to specify the filter for the records.
+	Condition string `protobuf:"bytes,2,opt,name=condition,proto3" json:"condition,omitempty"`
+	// logIDs allows to specify the list of logs explicitly. If it is provided, then the logsCondition will be ignored.
+	LogIDs []string `protobuf:"bytes,3,rep,name=logIDs,proto3" json:"logIDs,omitempty"`
+	// descending specifies that the result should be sorted in the record ID descending order.
+	Descending bool `protobuf:"varint,4,opt,name=descending,proto3" json:"descending,omitempty"`
+	// startRecordID defines the first record ID the result set may start from. In case of ascending order, the
+	// result will contain records with IDs are equal or GREATER the startRecordID. If the descending order
+	// is true, the result will contain records with IDs are equal or LOWER the startRecordID.
+	// If the startRecordID is empty the result will start from either first or latest record in the log, depending
+	// on the descending flag value.
+	StartRecordID string `protobuf:"bytes,5,opt,name=startRecordID,proto3" json:"startRecordID,omitempty"`
+	// limit contains the number of records to be returned
+	Limit int64 `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *QueryRecordsRequest) Reset() {
+	*x = QueryRecordsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_solaris_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueryRecordsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryRecordsRequest) ProtoMessage() {}
+
+func (x *QueryRecordsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_solaris_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryRecordsRequest.ProtoReflect.Descriptor instead.
 func (*QueryRecordsRequest) Descriptor() ([]byte, []int) {
-	return file_solaris_proto_rawDescGZIP(), []int{9}
+	return file_solaris_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *QueryRecordsRequest) GetLogsCondition() string {
+	if x != nil {
+		return x.LogsCondition
+	}
+	return ""
+}
+
+func (x *QueryRecordsRequest) GetCondition() string {
+	if x != nil {
+		return x.Condition
+	}
+	return ""
+}
+
+func (x *QueryRecordsRequest) GetLogIDs() []string {
+	if x != nil {
+		return x.LogIDs
+	}
+	return nil
+}
+
+func (x *QueryRecordsRequest) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+func (x *QueryRecordsRequest) GetStartRecordID() string {
+	if x != nil {
+		return x.StartRecordID
+	}
+	return ""
+}
+
+func (x *QueryRecordsRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// QueryRecordsResult describes the result for the records request
+type QueryRecordsResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// records is the list of records matched for the request
+	Records []*Record `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+	// nextPageID contains the next page ID for retrieving the next portion of records
+	NextPageID string `protobuf:"bytes,2,opt,name=nextPageID,proto3" json:"nextPageID,omitempty"`
+}
+
+func (x *QueryRecordsResult) Reset() {
+	*x = QueryRecordsResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_solaris_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueryRecordsResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryRecordsResult) ProtoMessage() {}
+
+func (x *QueryRecordsResult) ProtoReflect() protoreflect.Message {
+	mi := &file_solaris_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryRecordsResult.ProtoReflect.Descriptor instead.
+func (*QueryRecordsResult) Descriptor() ([]byte, []int) {
+	return file_solaris_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *QueryRecordsResult) GetRecords() []*Record {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+func (x *QueryRecordsResult) GetNextPageID() string {
+	if x != nil {
+		return x.NextPageID
+	}
+	return ""
+}
+
+// TailRecordsRequest contains arguments for following Log(s) records
+type TailRecordsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// logsCondition allows to specify the filter condition for selecting logs. The logs are selected
+	// once, when the request starts.
+	LogsCondition string `protobuf:"bytes,1,opt,name=logsCondition,proto3" json:"logsCondition,omitempty"`
+	// condition allows to specify the filter for the records.
+	Condition string `protobuf:"bytes,2,opt,name=condition,proto3" json:"condition,omitempty"`
+	// logIDs allows to specify the list of logs explicitly. If it is provided, then the logsCondition will be ignored.
+	LogIDs []string `protobuf:"bytes,3,rep,name=logIDs,proto3" json:"logIDs,omitempty"`
+	// startRecordID defines the first record ID the stream may start from. The stream will contain records with
+	// IDs are equal or GREATER the startRecordID. If the startRecordID is empty the stream will start from
+	// the first record of every log.
+	StartRecordID string `protobuf:"bytes,4,opt,name=startRecordID,proto3" json:"startRecordID,omitempty"`
+}
+
+func (x *TailRecordsRequest) Reset() {
+	*x = TailRecordsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_solaris_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TailRecordsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *QueryRecordsRequest) GetLogsCondition() string {
+func (*TailRecordsRequest) ProtoMessage() {}
+
+func (x *TailRecordsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_solaris_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TailRecordsRequest.ProtoReflect.Descriptor instead.
+func (*TailRecordsRequest) Descriptor() ([]byte, []int) {
+	return file_solaris_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *TailRecordsRequest) GetLogsCondition() string {
 	if x != nil {
 		return x.LogsCondition
 	}
 	return ""
 }
 
-func (x *QueryRecordsRequest) GetCondition() string {
+func (x *TailRecordsRequest) GetCondition() string {
 	if x != nil {
 		return x.Condition
 	}
 	return ""
 }
 
-func (x *QueryRecordsRequest) GetLogIDs() []string {
+func (x *TailRecordsRequest) GetLogIDs() []string {
 	if x != nil {
 		return x.LogIDs
 	}
 	return nil
 }
 
-func (x *QueryRecordsRequest) GetDescending() bool {
-	if x != nil {
-		return x.Descending
-	}
-	return false
-}
-
-func (x *QueryRecordsRequest) GetStartRecordID() string {
+func (x *TailRecordsRequest) GetStartRecordID() string {
 	if x != nil {
 		return x.StartRecordID
 	}
 	return ""
 }
 
-func (x *QueryRecordsRequest) GetLimit() int64 {
-	if x != nil {
-		return x.Limit
-	}
-	return 0
-}
-
-// QueryRecordsResult describes the result for the records request
-type QueryRecordsResult struct {
+// TailRecordsResult describes one portion of the records stream
+type TailRecordsResult struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// records is the list of records matched for the request
+	// records is the list of records sorted by the record IDs ascending order within every log.
+	// The records of different logs are not ordered relative to each other.
 	Records []*Record `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
-	// nextPageID contains the next page ID for retrieving the next portion of records
-	NextPageID string `protobuf:"bytes,2,opt,name=nextPageID,proto3" json:"nextPageID,omitempty"`
 }
 
-func (x *QueryRecordsResult) Reset() {
-	*x = QueryRecordsResult{}
+func (x *TailRecordsResult) Reset() {
+	*x = TailRecordsResult{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_solaris_proto_msgTypes[10]
+		mi := &file_solaris_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *QueryRecordsResult) String() string {
+func (x *TailRecordsResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*QueryRecordsResult) ProtoMessage() {}
+func (*TailRecordsResult) ProtoMessage() {}
 
-func (x *QueryRecordsResult) ProtoReflect() protoreflect.Message {
-	mi := &file_solaris_proto_msgTypes[10]
+func (x *TailRecordsResult) ProtoReflect() protoreflect.Message {
+	mi := &file_solaris_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -705,25 +1992,18 @@ func (x *QueryRecordsResult) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use QueryRecordsResult.ProtoReflect.Descriptor instead.
-func (*QueryRecordsResult) Descriptor() ([]byte, []int) {
-	return file_solaris_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use TailRecordsResult.ProtoReflect.Descriptor instead.
+func (*TailRecordsResult) Descriptor() ([]byte, []int) {
+	return file_solaris_proto_rawDescGZIP(), []int{28}
 }
 
-func (x *QueryRecordsResult) GetRecords() []*Record {
+func (x *TailRecordsResult) GetRecords() []*Record {
 	if x != nil {
 		return x.Records
 	}
 	return nil
 }
 
-func (x *QueryRecordsResult) GetNextPageID() string {
-	if x != nil {
-		return x.NextPageID
-	}
-	return ""
-}
-
 var File_solaris_proto protoreflect.FileDescriptor
 
 var file_solaris_proto_rawDesc = []byte{
@@ -739,7 +2019,7 @@ var file_solaris_proto_rawDesc = []byte{
 	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72,
 	0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f,
 	0x61, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61,
-	0x64, 0x22, 0x8b, 0x02, 0x0a, 0x03, 0x4c, 0x6f, 0x67, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x44, 0x18,
+	0x64, 0x22, 0xda, 0x02, 0x0a, 0x03, 0x4c, 0x6f, 0x67, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x44, 0x18,
 	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x49, 0x44, 0x12, 0x2d, 0x0a, 0x04, 0x74, 0x61, 0x67,
 	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x73, 0x6f, 0x6c, 0x61, 0x72, 0x69,
 	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x67, 0x2e, 0x54, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74,
@@ -752,93 +2032,302 @@ var file_solaris_proto_rawDesc = []byte{
 	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
 	0x70, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x18, 0x0a, 0x07,
 	0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x72,
-	0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x1a, 0x37, 0x0a, 0x09, 0x54, 0x61, 0x67, 0x73, 0x45, 0x6e,
-	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
-	0x5a, 0x0a, 0x14, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x6f, 0x67, 0x49, 0x44,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x6f, 0x67, 0x49, 0x44, 0x12, 0x2c, 0x0a,
-	0x07, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12,
-	0x2e, 0x73, 0x6f, 0x6c, 0x61, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f,
-	0x72, 0x64, 0x52, 0x07, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x22, 0x2b, 0x0a, 0x13, 0x41,
-	0x70, 0x70, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x73, 0x75,
-	0x6c, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x64, 0x64, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x05, 0x61, 0x64, 0x64, 0x65, 0x64, 0x22, 0x5e, 0x0a, 0x10, 0x51, 0x75, 0x65, 0x72,
-	0x79, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09,
-	0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x61,
-	0x67, 0x65, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x61, 0x67, 0x65,
-	0x49, 0x44, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x6c, 0x0a, 0x0f, 0x51, 0x75, 0x65, 0x72,
-	0x79, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x23, 0x0a, 0x04, 0x6c,
-	0x6f, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x73, 0x6f, 0x6c, 0x61,
-	0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x04, 0x6c, 0x6f, 0x67, 0x73,
-	0x12, 0x1e, 0x0a, 0x0a, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x49, 0x44, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x49, 0x44,
-	0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x22, 0x31, 0x0a, 0x11, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x33, 0x0a, 0x09, 0x72, 0x65, 0x74, 0x65, 0x6e, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x73, 0x6f, 0x6c, 0x61,
+	0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x09, 0x72, 0x65, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x1a, 0x37, 0x0a, 0x09, 0x54, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x6b,
+	0x0a, 0x09, 0x52, 0x65, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x24, 0x0a, 0x0d, 0x6d,
+	0x61, 0x78, 0x41, 0x67, 0x65, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0d, 0x6d, 0x61, 0x78, 0x41, 0x67, 0x65, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64,
+	0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x61, 0x78, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x6d, 0x61, 0x78, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x61, 0x78, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x07, 0x6d, 0x61, 0x78, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x9a, 0x02, 0x0a, 0x14,
+	0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x6f, 0x67, 0x49, 0x44, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x6f, 0x67, 0x49, 0x44, 0x12, 0x2c, 0x0a, 0x07, 0x72, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x73, 0x6f,
+	0x6c, 0x61, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52,
+	0x07, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x65, 0x64, 0x75,
+	0x70, 0x4b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x65, 0x64, 0x75,
+	0x70, 0x4b, 0x65, 0x79, 0x12, 0x37, 0x0a, 0x14, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64,
+	0x4c, 0x61, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x49, 0x44, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x48, 0x00, 0x52, 0x14, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x4c, 0x61,
+	0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x49, 0x44, 0x88, 0x01, 0x01, 0x12, 0x37, 0x0a,
+	0x14, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x48, 0x01, 0x52, 0x14, 0x65,
+	0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x88, 0x01, 0x01, 0x42, 0x17, 0x0a, 0x15, 0x5f, 0x65, 0x78, 0x70, 0x65, 0x63,
+	0x74, 0x65, 0x64, 0x4c, 0x61, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x49, 0x44, 0x42,
+	0x17, 0x0a, 0x15, 0x5f, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x52, 0x65, 0x63, 0x6f,
+	0x72, 0x64, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0xe3, 0x01, 0x0a, 0x13, 0x41, 0x70, 0x70,
+	0x65, 0x6e, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x12, 0x14, 0x0a, 0x05, 0x61, 0x64, 0x64, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x05, 0x61, 0x64, 0x64, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x66, 0x69, 0x72, 0x73, 0x74, 0x49,
+	0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x66, 0x69, 0x72, 0x73, 0x74, 0x49, 0x44,
+	0x12, 0x16, 0x0a, 0x06, 0x6c, 0x61, 0x73, 0x74, 0x49, 0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x6c, 0x61, 0x73, 0x74, 0x49, 0x44, 0x12, 0x42, 0x0a, 0x0e, 0x66, 0x69, 0x72, 0x73,
+	0x74, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0e, 0x66, 0x69,
+	0x72, 0x73, 0x74, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x40, 0x0a, 0x0d,
+	0x6c, 0x61, 0x73, 0x74, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x0d, 0x6c, 0x61, 0x73, 0x74, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x77,
+	0x0a, 0x10, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x41,
+	0x63, 0x6b, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x65, 0x71, 0x4e, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x05, 0x73, 0x65, 0x71, 0x4e, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x6f, 0x67, 0x49,
+	0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x6f, 0x67, 0x49, 0x44, 0x12, 0x37,
+	0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f,
+	0x2e, 0x73, 0x6f, 0x6c, 0x61, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x70, 0x70, 0x65,
+	0x6e, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52,
+	0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x59, 0x0a, 0x19, 0x41, 0x70, 0x70, 0x65, 0x6e,
+	0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x3c, 0x0a, 0x08, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x73, 0x6f, 0x6c, 0x61, 0x72, 0x69, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x08, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x73, 0x22, 0x55, 0x0a, 0x18, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x63, 0x6f,
+	0x72, 0x64, 0x73, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x39,
+	0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1f, 0x2e, 0x73, 0x6f, 0x6c, 0x61, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x70, 0x70,
+	0x65, 0x6e, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0xd1, 0x01, 0x0a, 0x13, 0x50, 0x61,
+	0x74, 0x63, 0x68, 0x4c, 0x6f, 0x67, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x6f, 0x67, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x6c, 0x6f, 0x67, 0x49, 0x44, 0x12, 0x3a, 0x0a, 0x03, 0x73, 0x65, 0x74, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x73, 0x6f, 0x6c, 0x61, 0x72, 0x69, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x50, 0x61, 0x74, 0x63, 0x68, 0x4c, 0x6f, 0x67, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x65, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x03,
+	0x73, 0x65, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x1a, 0x36, 0x0a, 0x08, 0x53, 0x65, 0x74, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xc7, 0x01,
+	0x0a, 0x17, 0x42, 0x75, 0x6c, 0x6b, 0x50, 0x61, 0x74, 0x63, 0x68, 0x4c, 0x6f, 0x67, 0x54, 0x61,
+	0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6e,
+	0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f,
+	0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3e, 0x0a, 0x03, 0x73, 0x65, 0x74, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x73, 0x6f, 0x6c, 0x61, 0x72, 0x69, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x42, 0x75, 0x6c, 0x6b, 0x50, 0x61, 0x74, 0x63, 0x68, 0x4c, 0x6f, 0x67, 0x54, 0x61,
+	0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x65, 0x74, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x03, 0x73, 0x65, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x6d, 0x6f, 0x76,
+	0x65, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x1a,
+	0x36, 0x0a, 0x08, 0x53, 0x65, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x38, 0x0a, 0x16, 0x42, 0x75, 0x6c, 0x6b, 0x50,
+	0x61, 0x74, 0x63, 0x68, 0x4c, 0x6f, 0x67, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64, 0x49, 0x44, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64, 0x49, 0x44,
+	0x73, 0x22, 0xcc, 0x01, 0x0a, 0x10, 0x51, 0x75, 0x65, 0x72, 0x79, 0x4c, 0x6f, 0x67, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x61, 0x67, 0x65, 0x49, 0x44, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x61, 0x67, 0x65, 0x49, 0x44, 0x12, 0x14, 0x0a, 0x05,
+	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6c, 0x69, 0x6d,
+	0x69, 0x74, 0x12, 0x2e, 0x0a, 0x06, 0x73, 0x6f, 0x72, 0x74, 0x42, 0x79, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x16, 0x2e, 0x73, 0x6f, 0x6c, 0x61, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x4c, 0x6f, 0x67, 0x73, 0x53, 0x6f, 0x72, 0x74, 0x42, 0x79, 0x52, 0x06, 0x73, 0x6f, 0x72, 0x74,
+	0x42, 0x79, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x65, 0x73, 0x63, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x64, 0x65, 0x73, 0x63, 0x65, 0x6e, 0x64, 0x69,
+	0x6e, 0x67, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x6b, 0x69, 0x70, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x73, 0x6b, 0x69, 0x70, 0x54, 0x6f, 0x74, 0x61, 0x6c,
+	0x22, 0x6c, 0x0a, 0x0f, 0x51, 0x75, 0x65, 0x72, 0x79, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x12, 0x23, 0x0a, 0x04, 0x6c, 0x6f, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x0f, 0x2e, 0x73, 0x6f, 0x6c, 0x61, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c,
+	0x6f, 0x67, 0x52, 0x04, 0x6c, 0x6f, 0x67, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x6e, 0x65, 0x78, 0x74,
+	0x50, 0x61, 0x67, 0x65, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6e, 0x65,
+	0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x49, 0x44, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61,
+	0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x22, 0x5b,
+	0x0a, 0x13, 0x47, 0x65, 0x74, 0x54, 0x61, 0x67, 0x46, 0x61, 0x63, 0x65, 0x74, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x74, 0x61, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x36, 0x0a, 0x08, 0x54,
+	0x61, 0x67, 0x46, 0x61, 0x63, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x22, 0x58, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x54, 0x61, 0x67, 0x46, 0x61, 0x63,
+	0x65, 0x74, 0x73, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x2c, 0x0a, 0x06, 0x66, 0x61, 0x63,
+	0x65, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x73, 0x6f, 0x6c, 0x61,
+	0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x67, 0x46, 0x61, 0x63, 0x65, 0x74, 0x52,
+	0x06, 0x66, 0x61, 0x63, 0x65, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x22, 0x31, 0x0a,
+	0x11, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x22, 0x32, 0x0a, 0x10, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x49,
+	0x44, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x64, 0x49, 0x44, 0x73, 0x22, 0x4b, 0x0a, 0x13, 0x55, 0x6e, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65,
 	0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x63,
 	0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
-	0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x32, 0x0a, 0x10, 0x44, 0x65, 0x6c,
-	0x65, 0x74, 0x65, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x1e, 0x0a,
-	0x0a, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x49, 0x44, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
-	0x09, 0x52, 0x0a, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x49, 0x44, 0x73, 0x22, 0x23, 0x0a,
-	0x0b, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x14, 0x0a, 0x05,
-	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x74, 0x6f, 0x74,
-	0x61, 0x6c, 0x22, 0xcd, 0x01, 0x0a, 0x13, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x63, 0x6f,
-	0x72, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x24, 0x0a, 0x0d, 0x6c, 0x6f,
-	0x67, 0x73, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0d, 0x6c, 0x6f, 0x67, 0x73, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e,
-	0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16,
-	0x0a, 0x06, 0x6c, 0x6f, 0x67, 0x49, 0x44, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06,
-	0x6c, 0x6f, 0x67, 0x49, 0x44, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x65, 0x73, 0x63, 0x65, 0x6e,
-	0x64, 0x69, 0x6e, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x64, 0x65, 0x73, 0x63,
-	0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x24, 0x0a, 0x0d, 0x73, 0x74, 0x61, 0x72, 0x74, 0x52,
-	0x65, 0x63, 0x6f, 0x72, 0x64, 0x49, 0x44, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x73,
-	0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x49, 0x44, 0x12, 0x14, 0x0a, 0x05,
-	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6c, 0x69, 0x6d,
-	0x69, 0x74, 0x22, 0x62, 0x0a, 0x12, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x63, 0x6f, 0x72,
-	0x64, 0x73, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x2c, 0x0a, 0x07, 0x72, 0x65, 0x63, 0x6f,
-	0x72, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x73, 0x6f, 0x6c, 0x61,
-	0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x07, 0x72,
-	0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61,
-	0x67, 0x65, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6e, 0x65, 0x78, 0x74,
-	0x50, 0x61, 0x67, 0x65, 0x49, 0x44, 0x32, 0xe9, 0x03, 0x0a, 0x07, 0x53, 0x65, 0x72, 0x76, 0x69,
-	0x63, 0x65, 0x12, 0x2d, 0x0a, 0x09, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4c, 0x6f, 0x67, 0x12,
-	0x0f, 0x2e, 0x73, 0x6f, 0x6c, 0x61, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x67,
-	0x1a, 0x0f, 0x2e, 0x73, 0x6f, 0x6c, 0x61, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f,
-	0x67, 0x12, 0x2d, 0x0a, 0x09, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4c, 0x6f, 0x67, 0x12, 0x0f,
-	0x2e, 0x73, 0x6f, 0x6c, 0x61, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x67, 0x1a,
-	0x0f, 0x2e, 0x73, 0x6f, 0x6c, 0x61, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x67,
-	0x12, 0x46, 0x0a, 0x09, 0x51, 0x75, 0x65, 0x72, 0x79, 0x4c, 0x6f, 0x67, 0x73, 0x12, 0x1c, 0x2e,
-	0x73, 0x6f, 0x6c, 0x61, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79,
-	0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x73, 0x6f,
-	0x6c, 0x61, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x4c, 0x6f,
-	0x67, 0x73, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x49, 0x0a, 0x0a, 0x44, 0x65, 0x6c, 0x65,
-	0x74, 0x65, 0x4c, 0x6f, 0x67, 0x73, 0x12, 0x1d, 0x2e, 0x73, 0x6f, 0x6c, 0x61, 0x72, 0x69, 0x73,
-	0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x73, 0x6f, 0x6c, 0x61, 0x72, 0x69, 0x73, 0x2e,
-	0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65, 0x73,
-	0x75, 0x6c, 0x74, 0x12, 0x52, 0x0a, 0x0d, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x63,
-	0x6f, 0x72, 0x64, 0x73, 0x12, 0x20, 0x2e, 0x73, 0x6f, 0x6c, 0x61, 0x72, 0x69, 0x73, 0x2e, 0x76,
-	0x31, 0x2e, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x73, 0x6f, 0x6c, 0x61, 0x72, 0x69, 0x73,
-	0x2e, 0x76, 0x31, 0x2e, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
-	0x73, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x4f, 0x0a, 0x0c, 0x51, 0x75, 0x65, 0x72, 0x79,
-	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x1f, 0x2e, 0x73, 0x6f, 0x6c, 0x61, 0x72, 0x69,
-	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x73, 0x6f, 0x6c, 0x61, 0x72,
-	0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x63, 0x6f, 0x72,
-	0x64, 0x73, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x48, 0x0a, 0x0c, 0x43, 0x6f, 0x75, 0x6e,
-	0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x1f, 0x2e, 0x73, 0x6f, 0x6c, 0x61, 0x72,
-	0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x63, 0x6f, 0x72,
-	0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x73, 0x6f, 0x6c, 0x61,
-	0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x75,
-	0x6c, 0x74, 0x42, 0x16, 0x5a, 0x14, 0x2e, 0x2f, 0x73, 0x6f, 0x6c, 0x61, 0x72, 0x69, 0x73, 0x2f,
-	0x76, 0x31, 0x3b, 0x73, 0x6f, 0x6c, 0x61, 0x72, 0x69, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x33,
+	0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x6f, 0x67,
+	0x49, 0x44, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x6f, 0x67, 0x49, 0x44,
+	0x73, 0x22, 0x38, 0x0a, 0x12, 0x55, 0x6e, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4c, 0x6f, 0x67,
+	0x73, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x75, 0x6e, 0x64, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x64, 0x49, 0x44, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x75,
+	0x6e, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x49, 0x44, 0x73, 0x22, 0x8e, 0x01, 0x0a, 0x12,
+	0x54, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x6f, 0x67, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x6c, 0x6f, 0x67, 0x49, 0x44, 0x12, 0x26, 0x0a, 0x0e, 0x62, 0x65, 0x66, 0x6f,
+	0x72, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0e, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x49, 0x44,
+	0x12, 0x3a, 0x0a, 0x0a, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x0a, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x2d, 0x0a, 0x11,
+	0x54, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x07, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x22, 0x2a, 0x0a, 0x12, 0x47,
+	0x65, 0x74, 0x4c, 0x6f, 0x67, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x6f, 0x67, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x6c, 0x6f, 0x67, 0x49, 0x44, 0x22, 0xf4, 0x01, 0x0a, 0x08, 0x4c, 0x6f, 0x67, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x6f, 0x67, 0x49, 0x44, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x6f, 0x67, 0x49, 0x44, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x72, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x66, 0x69, 0x72, 0x73,
+	0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x49, 0x44, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0d, 0x66, 0x69, 0x72, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x49, 0x44, 0x12, 0x22,
+	0x0a, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x49, 0x44, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x49, 0x44, 0x12, 0x42, 0x0a, 0x0e, 0x6c, 0x61, 0x73, 0x74, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64,
+	0x65, 0x64, 0x41, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0e, 0x6c, 0x61, 0x73, 0x74, 0x41, 0x70, 0x70, 0x65,
+	0x6e, 0x64, 0x65, 0x64, 0x41, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x73,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x22, 0x23,
+	0x0a, 0x0b, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x74, 0x6f,
+	0x74, 0x61, 0x6c, 0x22, 0xcd, 0x01, 0x0a, 0x13, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x24, 0x0a, 0x0d, 0x6c,
+	0x6f, 0x67, 0x73, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0d, 0x6c, 0x6f, 0x67, 0x73, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x16, 0x0a, 0x06, 0x6c, 0x6f, 0x67, 0x49, 0x44, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x06, 0x6c, 0x6f, 0x67, 0x49, 0x44, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x65, 0x73, 0x63, 0x65,
+	0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x64, 0x65, 0x73,
+	0x63, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x24, 0x0a, 0x0d, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x49, 0x44, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x49, 0x44, 0x12, 0x14, 0x0a,
+	0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6c, 0x69,
+	0x6d, 0x69, 0x74, 0x22, 0x62, 0x0a, 0x12, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x63, 0x6f,
+	0x72, 0x64, 0x73, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x2c, 0x0a, 0x07, 0x72, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x73, 0x6f, 0x6c,
+	0x61, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x07,
+	0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x6e, 0x65, 0x78, 0x74, 0x50,
+	0x61, 0x67, 0x65, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6e, 0x65, 0x78,
+	0x74, 0x50, 0x61, 0x67, 0x65, 0x49, 0x44, 0x22, 0x96, 0x01, 0x0a, 0x12, 0x54, 0x61, 0x69, 0x6c,
+	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x24,
+	0x0a, 0x0d, 0x6c, 0x6f, 0x67, 0x73, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6c, 0x6f, 0x67, 0x73, 0x43, 0x6f, 0x6e, 0x64, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x6f, 0x67, 0x49, 0x44, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x06, 0x6c, 0x6f, 0x67, 0x49, 0x44, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x49, 0x44, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0d, 0x73, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x49, 0x44,
+	0x22, 0x41, 0x0a, 0x11, 0x54, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x2c, 0x0a, 0x07, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x73, 0x6f, 0x6c, 0x61, 0x72, 0x69, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x07, 0x72, 0x65, 0x63, 0x6f,
+	0x72, 0x64, 0x73, 0x2a, 0x5b, 0x0a, 0x0a, 0x4c, 0x6f, 0x67, 0x73, 0x53, 0x6f, 0x72, 0x74, 0x42,
+	0x79, 0x12, 0x13, 0x0a, 0x0f, 0x4c, 0x4f, 0x47, 0x53, 0x5f, 0x53, 0x4f, 0x52, 0x54, 0x5f, 0x42,
+	0x59, 0x5f, 0x49, 0x44, 0x10, 0x00, 0x12, 0x1b, 0x0a, 0x17, 0x4c, 0x4f, 0x47, 0x53, 0x5f, 0x53,
+	0x4f, 0x52, 0x54, 0x5f, 0x42, 0x59, 0x5f, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x44, 0x5f, 0x41,
+	0x54, 0x10, 0x01, 0x12, 0x1b, 0x0a, 0x17, 0x4c, 0x4f, 0x47, 0x53, 0x5f, 0x53, 0x4f, 0x52, 0x54,
+	0x5f, 0x42, 0x59, 0x5f, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x44, 0x5f, 0x41, 0x54, 0x10, 0x02,
+	0x32, 0xcb, 0x09, 0x0a, 0x07, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x2d, 0x0a, 0x09,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4c, 0x6f, 0x67, 0x12, 0x0f, 0x2e, 0x73, 0x6f, 0x6c, 0x61,
+	0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x67, 0x1a, 0x0f, 0x2e, 0x73, 0x6f, 0x6c,
+	0x61, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x67, 0x12, 0x2d, 0x0a, 0x09, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x4c, 0x6f, 0x67, 0x12, 0x0f, 0x2e, 0x73, 0x6f, 0x6c, 0x61, 0x72,
+	0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x67, 0x1a, 0x0f, 0x2e, 0x73, 0x6f, 0x6c, 0x61,
+	0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x67, 0x12, 0x40, 0x0a, 0x0c, 0x50, 0x61,
+	0x74, 0x63, 0x68, 0x4c, 0x6f, 0x67, 0x54, 0x61, 0x67, 0x73, 0x12, 0x1f, 0x2e, 0x73, 0x6f, 0x6c,
+	0x61, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x74, 0x63, 0x68, 0x4c, 0x6f, 0x67,
+	0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0f, 0x2e, 0x73, 0x6f,
+	0x6c, 0x61, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x67, 0x12, 0x5b, 0x0a, 0x10,
+	0x42, 0x75, 0x6c, 0x6b, 0x50, 0x61, 0x74, 0x63, 0x68, 0x4c, 0x6f, 0x67, 0x54, 0x61, 0x67, 0x73,
+	0x12, 0x23, 0x2e, 0x73, 0x6f, 0x6c, 0x61, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x75,
+	0x6c, 0x6b, 0x50, 0x61, 0x74, 0x63, 0x68, 0x4c, 0x6f, 0x67, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x73, 0x6f, 0x6c, 0x61, 0x72, 0x69, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x42, 0x75, 0x6c, 0x6b, 0x50, 0x61, 0x74, 0x63, 0x68, 0x4c, 0x6f, 0x67, 0x54,
+	0x61, 0x67, 0x73, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x46, 0x0a, 0x09, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x4c, 0x6f, 0x67, 0x73, 0x12, 0x1c, 0x2e, 0x73, 0x6f, 0x6c, 0x61, 0x72, 0x69, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x73, 0x6f, 0x6c, 0x61, 0x72, 0x69, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x12, 0x4f, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x54, 0x61, 0x67, 0x46, 0x61, 0x63, 0x65, 0x74,
+	0x73, 0x12, 0x1f, 0x2e, 0x73, 0x6f, 0x6c, 0x61, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47,
+	0x65, 0x74, 0x54, 0x61, 0x67, 0x46, 0x61, 0x63, 0x65, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x73, 0x6f, 0x6c, 0x61, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x47, 0x65, 0x74, 0x54, 0x61, 0x67, 0x46, 0x61, 0x63, 0x65, 0x74, 0x73, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x12, 0x49, 0x0a, 0x0a, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4c, 0x6f, 0x67, 0x73,
+	0x12, 0x1d, 0x2e, 0x73, 0x6f, 0x6c, 0x61, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1c, 0x2e, 0x73, 0x6f, 0x6c, 0x61, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x4f, 0x0a,
+	0x0c, 0x55, 0x6e, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4c, 0x6f, 0x67, 0x73, 0x12, 0x1f, 0x2e,
+	0x73, 0x6f, 0x6c, 0x61, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x6e, 0x64, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e,
+	0x2e, 0x73, 0x6f, 0x6c, 0x61, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x6e, 0x64, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x52,
+	0x0a, 0x0d, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x12,
+	0x20, 0x2e, 0x73, 0x6f, 0x6c, 0x61, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x70, 0x70,
+	0x65, 0x6e, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1f, 0x2e, 0x73, 0x6f, 0x6c, 0x61, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41,
+	0x70, 0x70, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x12, 0x59, 0x0a, 0x13, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x63, 0x6f,
+	0x72, 0x64, 0x73, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x20, 0x2e, 0x73, 0x6f, 0x6c, 0x61,
+	0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x73, 0x6f,
+	0x6c, 0x61, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x52,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x41, 0x63, 0x6b, 0x28, 0x01, 0x30, 0x01, 0x12, 0x61, 0x0a,
+	0x12, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x12, 0x25, 0x2e, 0x73, 0x6f, 0x6c, 0x61, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x73, 0x6f, 0x6c,
+	0x61, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x52, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x73, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x12, 0x4f, 0x0a, 0x0c, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73,
+	0x12, 0x1f, 0x2e, 0x73, 0x6f, 0x6c, 0x61, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75,
+	0x65, 0x72, 0x79, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1e, 0x2e, 0x73, 0x6f, 0x6c, 0x61, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x51,
+	0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x12, 0x48, 0x0a, 0x0c, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x73, 0x12, 0x1f, 0x2e, 0x73, 0x6f, 0x6c, 0x61, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x51,
+	0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x17, 0x2e, 0x73, 0x6f, 0x6c, 0x61, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x4c, 0x0a, 0x0b, 0x54,
+	0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x4c, 0x6f, 0x67, 0x12, 0x1e, 0x2e, 0x73, 0x6f, 0x6c,
+	0x61, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65,
+	0x4c, 0x6f, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x73, 0x6f, 0x6c,
+	0x61, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65,
+	0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x43, 0x0a, 0x0b, 0x47, 0x65, 0x74,
+	0x4c, 0x6f, 0x67, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x1e, 0x2e, 0x73, 0x6f, 0x6c, 0x61, 0x72,
+	0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x6f, 0x67, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x73, 0x6f, 0x6c, 0x61, 0x72,
+	0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x67, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x4e,
+	0x0a, 0x0b, 0x54, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x1e, 0x2e,
+	0x73, 0x6f, 0x6c, 0x61, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x69, 0x6c, 0x52,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e,
+	0x73, 0x6f, 0x6c, 0x61, 0x72, 0x69, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x69, 0x6c, 0x52,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x30, 0x01, 0x42, 0x16,
+	0x5a, 0x14, 0x2e, 0x2f, 0x73, 0x6f, 0x6c, 0x61, 0x72, 0x69, 0x73, 0x2f, 0x76, 0x31, 0x3b, 0x73,
+	0x6f, 0x6c, 0x61, 0x72, 0x69, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -853,49 +2342,102 @@ func file_solaris_proto_rawDescGZIP() []byte {
 	return file_solaris_proto_rawDescData
 }
 
-var file_solaris_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_solaris_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_solaris_proto_msgTypes = make([]protoimpl.MessageInfo, 32)
 var file_solaris_proto_goTypes = []interface{}{
-	(*Record)(nil),                // 0: solaris.v1.Record
-	(*Log)(nil),                   // 1: solaris.v1.Log
-	(*AppendRecordsRequest)(nil),  // 2: solaris.v1.AppendRecordsRequest
-	(*AppendRecordsResult)(nil),   // 3: solaris.v1.AppendRecordsResult
-	(*QueryLogsRequest)(nil),      // 4: solaris.v1.QueryLogsRequest
-	(*QueryLogsResult)(nil),       // 5: solaris.v1.QueryLogsResult
-	(*DeleteLogsRequest)(nil),     // 6: solaris.v1.DeleteLogsRequest
-	(*DeleteLogsResult)(nil),      // 7: solaris.v1.DeleteLogsResult
-	(*CountResult)(nil),           // 8: solaris.v1.CountResult
-	(*QueryRecordsRequest)(nil),   // 9: solaris.v1.QueryRecordsRequest
-	(*QueryRecordsResult)(nil),    // 10: solaris.v1.QueryRecordsResult
-	nil,                           // 11: solaris.v1.Log.TagsEntry
-	(*timestamppb.Timestamp)(nil), // 12: google.protobuf.Timestamp
+	(LogsSortBy)(0),                   // 0: solaris.v1.LogsSortBy
+	(*Record)(nil),                    // 1: solaris.v1.Record
+	(*Log)(nil),                       // 2: solaris.v1.Log
+	(*Retention)(nil),                 // 3: solaris.v1.Retention
+	(*AppendRecordsRequest)(nil),      // 4: solaris.v1.AppendRecordsRequest
+	(*AppendRecordsResult)(nil),       // 5: solaris.v1.AppendRecordsResult
+	(*AppendRecordsAck)(nil),          // 6: solaris.v1.AppendRecordsAck
+	(*AppendRecordsBatchRequest)(nil), // 7: solaris.v1.AppendRecordsBatchRequest
+	(*AppendRecordsBatchResult)(nil),  // 8: solaris.v1.AppendRecordsBatchResult
+	(*PatchLogTagsRequest)(nil),       // 9: solaris.v1.PatchLogTagsRequest
+	(*BulkPatchLogTagsRequest)(nil),   // 10: solaris.v1.BulkPatchLogTagsRequest
+	(*BulkPatchLogTagsResult)(nil),    // 11: solaris.v1.BulkPatchLogTagsResult
+	(*QueryLogsRequest)(nil),          // 12: solaris.v1.QueryLogsRequest
+	(*QueryLogsResult)(nil),           // 13: solaris.v1.QueryLogsResult
+	(*GetTagFacetsRequest)(nil),       // 14: solaris.v1.GetTagFacetsRequest
+	(*TagFacet)(nil),                  // 15: solaris.v1.TagFacet
+	(*GetTagFacetsResult)(nil),        // 16: solaris.v1.GetTagFacetsResult
+	(*DeleteLogsRequest)(nil),         // 17: solaris.v1.DeleteLogsRequest
+	(*DeleteLogsResult)(nil),          // 18: solaris.v1.DeleteLogsResult
+	(*UndeleteLogsRequest)(nil),       // 19: solaris.v1.UndeleteLogsRequest
+	(*UndeleteLogsResult)(nil),        // 20: solaris.v1.UndeleteLogsResult
+	(*TruncateLogRequest)(nil),        // 21: solaris.v1.TruncateLogRequest
+	(*TruncateLogResult)(nil),         // 22: solaris.v1.TruncateLogResult
+	(*GetLogStatsRequest)(nil),        // 23: solaris.v1.GetLogStatsRequest
+	(*LogStats)(nil),                  // 24: solaris.v1.LogStats
+	(*CountResult)(nil),               // 25: solaris.v1.CountResult
+	(*QueryRecordsRequest)(nil),       // 26: solaris.v1.QueryRecordsRequest
+	(*QueryRecordsResult)(nil),        // 27: solaris.v1.QueryRecordsResult
+	(*TailRecordsRequest)(nil),        // 28: solaris.v1.TailRecordsRequest
+	(*TailRecordsResult)(nil),         // 29: solaris.v1.TailRecordsResult
+	nil,                               // 30: solaris.v1.Log.TagsEntry
+	nil,                               // 31: solaris.v1.PatchLogTagsRequest.SetEntry
+	nil,                               // 32: solaris.v1.BulkPatchLogTagsRequest.SetEntry
+	(*timestamppb.Timestamp)(nil),     // 33: google.protobuf.Timestamp
 }
 var file_solaris_proto_depIdxs = []int32{
-	12, // 0: solaris.v1.Record.createdAt:type_name -> google.protobuf.Timestamp
-	11, // 1: solaris.v1.Log.tags:type_name -> solaris.v1.Log.TagsEntry
-	12, // 2: solaris.v1.Log.createdAt:type_name -> google.protobuf.Timestamp
-	12, // 3: solaris.v1.Log.updatedAt:type_name -> google.protobuf.Timestamp
-	0,  // 4: solaris.v1.AppendRecordsRequest.records:type_name -> solaris.v1.Record
-	1,  // 5: solaris.v1.QueryLogsResult.logs:type_name -> solaris.v1.Log
-	0,  // 6: solaris.v1.QueryRecordsResult.records:type_name -> solaris.v1.Record
-	1,  // 7: solaris.v1.Service.CreateLog:input_type -> solaris.v1.Log
-	1,  // 8: solaris.v1.Service.UpdateLog:input_type -> solaris.v1.Log
-	4,  // 9: solaris.v1.Service.QueryLogs:input_type -> solaris.v1.QueryLogsRequest
-	6,  // 10: solaris.v1.Service.DeleteLogs:input_type -> solaris.v1.DeleteLogsRequest
-	2,  // 11: solaris.v1.Service.AppendRecords:input_type -> solaris.v1.AppendRecordsRequest
-	9,  // 12: solaris.v1.Service.QueryRecords:input_type -> solaris.v1.QueryRecordsRequest
-	9,  // 13: solaris.v1.Service.CountRecords:input_type -> solaris.v1.QueryRecordsRequest
-	1,  // 14: solaris.v1.Service.CreateLog:output_type -> solaris.v1.Log
-	1,  // 15: solaris.v1.Service.UpdateLog:output_type -> solaris.v1.Log
-	5,  // 16: solaris.v1.Service.QueryLogs:output_type -> solaris.v1.QueryLogsResult
-	7,  // 17: solaris.v1.Service.DeleteLogs:output_type -> solaris.v1.DeleteLogsResult
-	3,  // 18: solaris.v1.Service.AppendRecords:output_type -> solaris.v1.AppendRecordsResult
-	10, // 19: solaris.v1.Service.QueryRecords:output_type -> solaris.v1.QueryRecordsResult
-	8,  // 20: solaris.v1.Service.CountRecords:output_type -> solaris.v1.CountResult
-	14, // [14:21] is the sub-list for method output_type
-	7,  // [7:14] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	33, // 0: solaris.v1.Record.createdAt:type_name -> google.protobuf.Timestamp
+	30, // 1: solaris.v1.Log.tags:type_name -> solaris.v1.Log.TagsEntry
+	33, // 2: solaris.v1.Log.createdAt:type_name -> google.protobuf.Timestamp
+	33, // 3: solaris.v1.Log.updatedAt:type_name -> google.protobuf.Timestamp
+	3,  // 4: solaris.v1.Log.retention:type_name -> solaris.v1.Retention
+	1,  // 5: solaris.v1.AppendRecordsRequest.records:type_name -> solaris.v1.Record
+	33, // 6: solaris.v1.AppendRecordsResult.firstCreatedAt:type_name -> google.protobuf.Timestamp
+	33, // 7: solaris.v1.AppendRecordsResult.lastCreatedAt:type_name -> google.protobuf.Timestamp
+	5,  // 8: solaris.v1.AppendRecordsAck.result:type_name -> solaris.v1.AppendRecordsResult
+	4,  // 9: solaris.v1.AppendRecordsBatchRequest.requests:type_name -> solaris.v1.AppendRecordsRequest
+	5,  // 10: solaris.v1.AppendRecordsBatchResult.results:type_name -> solaris.v1.AppendRecordsResult
+	31, // 11: solaris.v1.PatchLogTagsRequest.set:type_name -> solaris.v1.PatchLogTagsRequest.SetEntry
+	32, // 12: solaris.v1.BulkPatchLogTagsRequest.set:type_name -> solaris.v1.BulkPatchLogTagsRequest.SetEntry
+	0,  // 13: solaris.v1.QueryLogsRequest.sortBy:type_name -> solaris.v1.LogsSortBy
+	2,  // 14: solaris.v1.QueryLogsResult.logs:type_name -> solaris.v1.Log
+	15, // 15: solaris.v1.GetTagFacetsResult.facets:type_name -> solaris.v1.TagFacet
+	33, // 16: solaris.v1.TruncateLogRequest.beforeTime:type_name -> google.protobuf.Timestamp
+	33, // 17: solaris.v1.LogStats.lastAppendedAt:type_name -> google.protobuf.Timestamp
+	1,  // 18: solaris.v1.QueryRecordsResult.records:type_name -> solaris.v1.Record
+	1,  // 19: solaris.v1.TailRecordsResult.records:type_name -> solaris.v1.Record
+	2,  // 20: solaris.v1.Service.CreateLog:input_type -> solaris.v1.Log
+	2,  // 21: solaris.v1.Service.UpdateLog:input_type -> solaris.v1.Log
+	9,  // 22: solaris.v1.Service.PatchLogTags:input_type -> solaris.v1.PatchLogTagsRequest
+	10, // 23: solaris.v1.Service.BulkPatchLogTags:input_type -> solaris.v1.BulkPatchLogTagsRequest
+	12, // 24: solaris.v1.Service.QueryLogs:input_type -> solaris.v1.QueryLogsRequest
+	14, // 25: solaris.v1.Service.GetTagFacets:input_type -> solaris.v1.GetTagFacetsRequest
+	17, // 26: solaris.v1.Service.DeleteLogs:input_type -> solaris.v1.DeleteLogsRequest
+	19, // 27: solaris.v1.Service.UndeleteLogs:input_type -> solaris.v1.UndeleteLogsRequest
+	4,  // 28: solaris.v1.Service.AppendRecords:input_type -> solaris.v1.AppendRecordsRequest
+	4,  // 29: solaris.v1.Service.AppendRecordsStream:input_type -> solaris.v1.AppendRecordsRequest
+	7,  // 30: solaris.v1.Service.AppendRecordsBatch:input_type -> solaris.v1.AppendRecordsBatchRequest
+	26, // 31: solaris.v1.Service.QueryRecords:input_type -> solaris.v1.QueryRecordsRequest
+	26, // 32: solaris.v1.Service.CountRecords:input_type -> solaris.v1.QueryRecordsRequest
+	21, // 33: solaris.v1.Service.TruncateLog:input_type -> solaris.v1.TruncateLogRequest
+	23, // 34: solaris.v1.Service.GetLogStats:input_type -> solaris.v1.GetLogStatsRequest
+	28, // 35: solaris.v1.Service.TailRecords:input_type -> solaris.v1.TailRecordsRequest
+	2,  // 36: solaris.v1.Service.CreateLog:output_type -> solaris.v1.Log
+	2,  // 37: solaris.v1.Service.UpdateLog:output_type -> solaris.v1.Log
+	2,  // 38: solaris.v1.Service.PatchLogTags:output_type -> solaris.v1.Log
+	11, // 39: solaris.v1.Service.BulkPatchLogTags:output_type -> solaris.v1.BulkPatchLogTagsResult
+	13, // 40: solaris.v1.Service.QueryLogs:output_type -> solaris.v1.QueryLogsResult
+	16, // 41: solaris.v1.Service.GetTagFacets:output_type -> solaris.v1.GetTagFacetsResult
+	18, // 42: solaris.v1.Service.DeleteLogs:output_type -> solaris.v1.DeleteLogsResult
+	20, // 43: solaris.v1.Service.UndeleteLogs:output_type -> solaris.v1.UndeleteLogsResult
+	5,  // 44: solaris.v1.Service.AppendRecords:output_type -> solaris.v1.AppendRecordsResult
+	6,  // 45: solaris.v1.Service.AppendRecordsStream:output_type -> solaris.v1.AppendRecordsAck
+	8,  // 46: solaris.v1.Service.AppendRecordsBatch:output_type -> solaris.v1.AppendRecordsBatchResult
+	27, // 47: solaris.v1.Service.QueryRecords:output_type -> solaris.v1.QueryRecordsResult
+	25, // 48: solaris.v1.Service.CountRecords:output_type -> solaris.v1.CountResult
+	22, // 49: solaris.v1.Service.TruncateLog:output_type -> solaris.v1.TruncateLogResult
+	24, // 50: solaris.v1.Service.GetLogStats:output_type -> solaris.v1.LogStats
+	29, // 51: solaris.v1.Service.TailRecords:output_type -> solaris.v1.TailRecordsResult
+	36, // [36:52] is the sub-list for method output_type
+	20, // [20:36] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
 }
 
 func init() { file_solaris_proto_init() }
@@ -929,7 +2471,7 @@ func file_solaris_proto_init() {
 			}
 		}
 		file_solaris_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AppendRecordsRequest); i {
+			switch v := v.(*Retention); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -941,7 +2483,7 @@ func file_solaris_proto_init() {
 			}
 		}
 		file_solaris_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AppendRecordsResult); i {
+			switch v := v.(*AppendRecordsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -953,7 +2495,7 @@ func file_solaris_proto_init() {
 			}
 		}
 		file_solaris_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*QueryLogsRequest); i {
+			switch v := v.(*AppendRecordsResult); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -965,7 +2507,7 @@ func file_solaris_proto_init() {
 			}
 		}
 		file_solaris_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*QueryLogsResult); i {
+			switch v := v.(*AppendRecordsAck); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -977,7 +2519,7 @@ func file_solaris_proto_init() {
 			}
 		}
 		file_solaris_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteLogsRequest); i {
+			switch v := v.(*AppendRecordsBatchRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -989,7 +2531,7 @@ func file_solaris_proto_init() {
 			}
 		}
 		file_solaris_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteLogsResult); i {
+			switch v := v.(*AppendRecordsBatchResult); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1001,7 +2543,7 @@ func file_solaris_proto_init() {
 			}
 		}
 		file_solaris_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CountResult); i {
+			switch v := v.(*PatchLogTagsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1013,7 +2555,7 @@ func file_solaris_proto_init() {
 			}
 		}
 		file_solaris_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*QueryRecordsRequest); i {
+			switch v := v.(*BulkPatchLogTagsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1025,6 +2567,198 @@ func file_solaris_proto_init() {
 			}
 		}
 		file_solaris_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BulkPatchLogTagsResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_solaris_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueryLogsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_solaris_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueryLogsResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_solaris_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTagFacetsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_solaris_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TagFacet); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_solaris_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTagFacetsResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_solaris_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteLogsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_solaris_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteLogsResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_solaris_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UndeleteLogsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_solaris_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UndeleteLogsResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_solaris_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TruncateLogRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_solaris_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TruncateLogResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_solaris_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetLogStatsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_solaris_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LogStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_solaris_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CountResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_solaris_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueryRecordsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_solaris_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*QueryRecordsResult); i {
 			case 0:
 				return &v.state
@@ -1036,19 +2770,45 @@ func file_solaris_proto_init() {
 				return nil
 			}
 		}
+		file_solaris_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TailRecordsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_solaris_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TailRecordsResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
+	file_solaris_proto_msgTypes[3].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_solaris_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   12,
+			NumEnums:      1,
+			NumMessages:   32,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_solaris_proto_goTypes,
 		DependencyIndexes: file_solaris_proto_depIdxs,
+		EnumInfos:         file_solaris_proto_enumTypes,
 		MessageInfos:      file_solaris_proto_msgTypes,
 	}.Build()
 	File_solaris_proto = out.File
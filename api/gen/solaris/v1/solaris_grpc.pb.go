@@ -19,13 +19,22 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Service_CreateLog_FullMethodName     = "/solaris.v1.Service/CreateLog"
-	Service_UpdateLog_FullMethodName     = "/solaris.v1.Service/UpdateLog"
-	Service_QueryLogs_FullMethodName     = "/solaris.v1.Service/QueryLogs"
-	Service_DeleteLogs_FullMethodName    = "/solaris.v1.Service/DeleteLogs"
-	Service_AppendRecords_FullMethodName = "/solaris.v1.Service/AppendRecords"
-	Service_QueryRecords_FullMethodName  = "/solaris.v1.Service/QueryRecords"
-	Service_CountRecords_FullMethodName  = "/solaris.v1.Service/CountRecords"
+	Service_CreateLog_FullMethodName           = "/solaris.v1.Service/CreateLog"
+	Service_UpdateLog_FullMethodName           = "/solaris.v1.Service/UpdateLog"
+	Service_PatchLogTags_FullMethodName        = "/solaris.v1.Service/PatchLogTags"
+	Service_BulkPatchLogTags_FullMethodName    = "/solaris.v1.Service/BulkPatchLogTags"
+	Service_QueryLogs_FullMethodName           = "/solaris.v1.Service/QueryLogs"
+	Service_GetTagFacets_FullMethodName        = "/solaris.v1.Service/GetTagFacets"
+	Service_DeleteLogs_FullMethodName          = "/solaris.v1.Service/DeleteLogs"
+	Service_UndeleteLogs_FullMethodName        = "/solaris.v1.Service/UndeleteLogs"
+	Service_AppendRecords_FullMethodName       = "/solaris.v1.Service/AppendRecords"
+	Service_AppendRecordsStream_FullMethodName = "/solaris.v1.Service/AppendRecordsStream"
+	Service_AppendRecordsBatch_FullMethodName  = "/solaris.v1.Service/AppendRecordsBatch"
+	Service_QueryRecords_FullMethodName        = "/solaris.v1.Service/QueryRecords"
+	Service_CountRecords_FullMethodName        = "/solaris.v1.Service/CountRecords"
+	Service_TruncateLog_FullMethodName         = "/solaris.v1.Service/TruncateLog"
+	Service_GetLogStats_FullMethodName         = "/solaris.v1.Service/GetLogStats"
+	Service_TailRecords_FullMethodName         = "/solaris.v1.Service/TailRecords"
 )
 
 // ServiceClient is the client API for Service service.
@@ -34,19 +43,46 @@ const (
 type ServiceClient interface {
 	// CreateLog creates then new log
 	CreateLog(ctx context.Context, in *Log, opts ...grpc.CallOption) (*Log, error)
-	// UpdateLog changes the log settings (tags)
+	// UpdateLog changes the log settings (tags). If the log version is provided, but it is not the current one,
+	// the update fails with the FAILED_PRECONDITION status.
 	UpdateLog(ctx context.Context, in *Log, opts ...grpc.CallOption) (*Log, error)
-	// QueryLogs requests list of logs by the query request ordered by the log IDs ascending order
+	// PatchLogTags sets and removes the specified log tags, the other log tags are kept as is. If the log version
+	// is provided, but it is not the current one, the patch fails with the FAILED_PRECONDITION status.
+	PatchLogTags(ctx context.Context, in *PatchLogTagsRequest, opts ...grpc.CallOption) (*Log, error)
+	// BulkPatchLogTags applies the tags patch to every log matching the condition
+	BulkPatchLogTags(ctx context.Context, in *BulkPatchLogTagsRequest, opts ...grpc.CallOption) (*BulkPatchLogTagsResult, error)
+	// QueryLogs requests list of logs by the query request. The logs are ordered by the log IDs ascending order
+	// by default, or by the requested sortBy field and direction.
 	QueryLogs(ctx context.Context, in *QueryLogsRequest, opts ...grpc.CallOption) (*QueryLogsResult, error)
+	// GetTagFacets returns the distinct tag names of the logs, or the distinct values of the tag if it is
+	// specified, with the number of logs for every name (value)
+	GetTagFacets(ctx context.Context, in *GetTagFacetsRequest, opts ...grpc.CallOption) (*GetTagFacetsResult, error)
 	// DeleteLogs removes one or more logs
 	DeleteLogs(ctx context.Context, in *DeleteLogsRequest, opts ...grpc.CallOption) (*DeleteLogsResult, error)
+	// UndeleteLogs restores the logs marked for deletion, until they are purged by the garbage collector
+	UndeleteLogs(ctx context.Context, in *UndeleteLogsRequest, opts ...grpc.CallOption) (*UndeleteLogsResult, error)
 	// AppendRecords appends a bunch of records to the log
 	AppendRecords(ctx context.Context, in *AppendRecordsRequest, opts ...grpc.CallOption) (*AppendRecordsResult, error)
+	// AppendRecordsStream allows to append records to one or many logs via the stream of AppendRecordsRequest
+	// messages. Every request is acknowledged by the AppendRecordsAck message in the order of the requests. The stream
+	// is interrupted by the first error, the requests acknowledged before the error are persisted.
+	AppendRecordsStream(ctx context.Context, opts ...grpc.CallOption) (Service_AppendRecordsStreamClient, error)
+	// AppendRecordsBatch appends records to several logs atomically: either all the records are added, or none of them.
+	// Every log may be met in the batch only once.
+	AppendRecordsBatch(ctx context.Context, in *AppendRecordsBatchRequest, opts ...grpc.CallOption) (*AppendRecordsBatchResult, error)
 	// QueryRecords read records from one or many logs, merging them together into the result set
 	// sorted in ascending or descending order by the records IDs (timestamps)
 	QueryRecords(ctx context.Context, in *QueryRecordsRequest, opts ...grpc.CallOption) (*QueryRecordsResult, error)
 	// CountRecords allows to count the number of records that matches QueryRecordsRequest
 	CountRecords(ctx context.Context, in *QueryRecordsRequest, opts ...grpc.CallOption) (*CountResult, error)
+	// TruncateLog removes all the records of the log before the given record ID or time. The whole chunks
+	// before the point are deleted, the records of the partially covered chunk are hidden from the readers.
+	TruncateLog(ctx context.Context, in *TruncateLogRequest, opts ...grpc.CallOption) (*TruncateLogResult, error)
+	// GetLogStats returns the log records statistics
+	GetLogStats(ctx context.Context, in *GetLogStatsRequest, opts ...grpc.CallOption) (*LogStats, error)
+	// TailRecords streams the records of one or many logs, starting from the startRecordID, and then pushes
+	// the new records as soon as they are appended to the logs. The stream is active until the client cancels it.
+	TailRecords(ctx context.Context, in *TailRecordsRequest, opts ...grpc.CallOption) (Service_TailRecordsClient, error)
 }
 
 type serviceClient struct {
@@ -75,6 +111,24 @@ func (c *serviceClient) UpdateLog(ctx context.Context, in *Log, opts ...grpc.Cal
 	return out, nil
 }
 
+func (c *serviceClient) PatchLogTags(ctx context.Context, in *PatchLogTagsRequest, opts ...grpc.CallOption) (*Log, error) {
+	out := new(Log)
+	err := c.cc.Invoke(ctx, Service_PatchLogTags_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) BulkPatchLogTags(ctx context.Context, in *BulkPatchLogTagsRequest, opts ...grpc.CallOption) (*BulkPatchLogTagsResult, error) {
+	out := new(BulkPatchLogTagsResult)
+	err := c.cc.Invoke(ctx, Service_BulkPatchLogTags_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *serviceClient) QueryLogs(ctx context.Context, in *QueryLogsRequest, opts ...grpc.CallOption) (*QueryLogsResult, error) {
 	out := new(QueryLogsResult)
 	err := c.cc.Invoke(ctx, Service_QueryLogs_FullMethodName, in, out, opts...)
@@ -84,6 +138,15 @@ func (c *serviceClient) QueryLogs(ctx context.Context, in *QueryLogsRequest, opt
 	return out, nil
 }
 
+func (c *serviceClient) GetTagFacets(ctx context.Context, in *GetTagFacetsRequest, opts ...grpc.CallOption) (*GetTagFacetsResult, error) {
+	out := new(GetTagFacetsResult)
+	err := c.cc.Invoke(ctx, Service_GetTagFacets_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *serviceClient) DeleteLogs(ctx context.Context, in *DeleteLogsRequest, opts ...grpc.CallOption) (*DeleteLogsResult, error) {
 	out := new(DeleteLogsResult)
 	err := c.cc.Invoke(ctx, Service_DeleteLogs_FullMethodName, in, out, opts...)
@@ -93,6 +156,15 @@ func (c *serviceClient) DeleteLogs(ctx context.Context, in *DeleteLogsRequest, o
 	return out, nil
 }
 
+func (c *serviceClient) UndeleteLogs(ctx context.Context, in *UndeleteLogsRequest, opts ...grpc.CallOption) (*UndeleteLogsResult, error) {
+	out := new(UndeleteLogsResult)
+	err := c.cc.Invoke(ctx, Service_UndeleteLogs_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *serviceClient) AppendRecords(ctx context.Context, in *AppendRecordsRequest, opts ...grpc.CallOption) (*AppendRecordsResult, error) {
 	out := new(AppendRecordsResult)
 	err := c.cc.Invoke(ctx, Service_AppendRecords_FullMethodName, in, out, opts...)
@@ -102,6 +174,46 @@ func (c *serviceClient) AppendRecords(ctx context.Context, in *AppendRecordsRequ
 	return out, nil
 }
 
+func (c *serviceClient) AppendRecordsStream(ctx context.Context, opts ...grpc.CallOption) (Service_AppendRecordsStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Service_ServiceDesc.Streams[0], Service_AppendRecordsStream_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &serviceAppendRecordsStreamClient{stream}
+	return x, nil
+}
+
+type Service_AppendRecordsStreamClient interface {
+	Send(*AppendRecordsRequest) error
+	Recv() (*AppendRecordsAck, error)
+	grpc.ClientStream
+}
+
+type serviceAppendRecordsStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *serviceAppendRecordsStreamClient) Send(m *AppendRecordsRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *serviceAppendRecordsStreamClient) Recv() (*AppendRecordsAck, error) {
+	m := new(AppendRecordsAck)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *serviceClient) AppendRecordsBatch(ctx context.Context, in *AppendRecordsBatchRequest, opts ...grpc.CallOption) (*AppendRecordsBatchResult, error) {
+	out := new(AppendRecordsBatchResult)
+	err := c.cc.Invoke(ctx, Service_AppendRecordsBatch_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *serviceClient) QueryRecords(ctx context.Context, in *QueryRecordsRequest, opts ...grpc.CallOption) (*QueryRecordsResult, error) {
 	out := new(QueryRecordsResult)
 	err := c.cc.Invoke(ctx, Service_QueryRecords_FullMethodName, in, out, opts...)
@@ -120,25 +232,102 @@ func (c *serviceClient) CountRecords(ctx context.Context, in *QueryRecordsReques
 	return out, nil
 }
 
+func (c *serviceClient) TruncateLog(ctx context.Context, in *TruncateLogRequest, opts ...grpc.CallOption) (*TruncateLogResult, error) {
+	out := new(TruncateLogResult)
+	err := c.cc.Invoke(ctx, Service_TruncateLog_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) GetLogStats(ctx context.Context, in *GetLogStatsRequest, opts ...grpc.CallOption) (*LogStats, error) {
+	out := new(LogStats)
+	err := c.cc.Invoke(ctx, Service_GetLogStats_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) TailRecords(ctx context.Context, in *TailRecordsRequest, opts ...grpc.CallOption) (Service_TailRecordsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Service_ServiceDesc.Streams[1], Service_TailRecords_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &serviceTailRecordsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Service_TailRecordsClient interface {
+	Recv() (*TailRecordsResult, error)
+	grpc.ClientStream
+}
+
+type serviceTailRecordsClient struct {
+	grpc.ClientStream
+}
+
+func (x *serviceTailRecordsClient) Recv() (*TailRecordsResult, error) {
+	m := new(TailRecordsResult)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // ServiceServer is the server API for Service service.
 // All implementations must embed UnimplementedServiceServer
 // for forward compatibility
 type ServiceServer interface {
 	// CreateLog creates then new log
 	CreateLog(context.Context, *Log) (*Log, error)
-	// UpdateLog changes the log settings (tags)
+	// UpdateLog changes the log settings (tags). If the log version is provided, but it is not the current one,
+	// the update fails with the FAILED_PRECONDITION status.
 	UpdateLog(context.Context, *Log) (*Log, error)
-	// QueryLogs requests list of logs by the query request ordered by the log IDs ascending order
+	// PatchLogTags sets and removes the specified log tags, the other log tags are kept as is. If the log version
+	// is provided, but it is not the current one, the patch fails with the FAILED_PRECONDITION status.
+	PatchLogTags(context.Context, *PatchLogTagsRequest) (*Log, error)
+	// BulkPatchLogTags applies the tags patch to every log matching the condition
+	BulkPatchLogTags(context.Context, *BulkPatchLogTagsRequest) (*BulkPatchLogTagsResult, error)
+	// QueryLogs requests list of logs by the query request. The logs are ordered by the log IDs ascending order
+	// by default, or by the requested sortBy field and direction.
 	QueryLogs(context.Context, *QueryLogsRequest) (*QueryLogsResult, error)
+	// GetTagFacets returns the distinct tag names of the logs, or the distinct values of the tag if it is
+	// specified, with the number of logs for every name (value)
+	GetTagFacets(context.Context, *GetTagFacetsRequest) (*GetTagFacetsResult, error)
 	// DeleteLogs removes one or more logs
 	DeleteLogs(context.Context, *DeleteLogsRequest) (*DeleteLogsResult, error)
+	// UndeleteLogs restores the logs marked for deletion, until they are purged by the garbage collector
+	UndeleteLogs(context.Context, *UndeleteLogsRequest) (*UndeleteLogsResult, error)
 	// AppendRecords appends a bunch of records to the log
 	AppendRecords(context.Context, *AppendRecordsRequest) (*AppendRecordsResult, error)
+	// AppendRecordsStream allows to append records to one or many logs via the stream of AppendRecordsRequest
+	// messages. Every request is acknowledged by the AppendRecordsAck message in the order of the requests. The stream
+	// is interrupted by the first error, the requests acknowledged before the error are persisted.
+	AppendRecordsStream(Service_AppendRecordsStreamServer) error
+	// AppendRecordsBatch appends records to several logs atomically: either all the records are added, or none of them.
+	// Every log may be met in the batch only once.
+	AppendRecordsBatch(context.Context, *AppendRecordsBatchRequest) (*AppendRecordsBatchResult, error)
 	// QueryRecords read records from one or many logs, merging them together into the result set
 	// sorted in ascending or descending order by the records IDs (timestamps)
 	QueryRecords(context.Context, *QueryRecordsRequest) (*QueryRecordsResult, error)
 	// CountRecords allows to count the number of records that matches QueryRecordsRequest
 	CountRecords(context.Context, *QueryRecordsRequest) (*CountResult, error)
+	// TruncateLog removes all the records of the log before the given record ID or time. The whole chunks
+	// before the point are deleted, the records of the partially covered chunk are hidden from the readers.
+	TruncateLog(context.Context, *TruncateLogRequest) (*TruncateLogResult, error)
+	// GetLogStats returns the log records statistics
+	GetLogStats(context.Context, *GetLogStatsRequest) (*LogStats, error)
+	// TailRecords streams the records of one or many logs, starting from the startRecordID, and then pushes
+	// the new records as soon as they are appended to the logs. The stream is active until the client cancels it.
+	TailRecords(*TailRecordsRequest, Service_TailRecordsServer) error
 	mustEmbedUnimplementedServiceServer()
 }
 
@@ -152,21 +341,48 @@ func (UnimplementedServiceServer) CreateLog(context.Context, *Log) (*Log, error)
 func (UnimplementedServiceServer) UpdateLog(context.Context, *Log) (*Log, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateLog not implemented")
 }
+func (UnimplementedServiceServer) PatchLogTags(context.Context, *PatchLogTagsRequest) (*Log, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PatchLogTags not implemented")
+}
+func (UnimplementedServiceServer) BulkPatchLogTags(context.Context, *BulkPatchLogTagsRequest) (*BulkPatchLogTagsResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkPatchLogTags not implemented")
+}
 func (UnimplementedServiceServer) QueryLogs(context.Context, *QueryLogsRequest) (*QueryLogsResult, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method QueryLogs not implemented")
 }
+func (UnimplementedServiceServer) GetTagFacets(context.Context, *GetTagFacetsRequest) (*GetTagFacetsResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTagFacets not implemented")
+}
 func (UnimplementedServiceServer) DeleteLogs(context.Context, *DeleteLogsRequest) (*DeleteLogsResult, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteLogs not implemented")
 }
+func (UnimplementedServiceServer) UndeleteLogs(context.Context, *UndeleteLogsRequest) (*UndeleteLogsResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UndeleteLogs not implemented")
+}
 func (UnimplementedServiceServer) AppendRecords(context.Context, *AppendRecordsRequest) (*AppendRecordsResult, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AppendRecords not implemented")
 }
+func (UnimplementedServiceServer) AppendRecordsStream(Service_AppendRecordsStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method AppendRecordsStream not implemented")
+}
+func (UnimplementedServiceServer) AppendRecordsBatch(context.Context, *AppendRecordsBatchRequest) (*AppendRecordsBatchResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AppendRecordsBatch not implemented")
+}
 func (UnimplementedServiceServer) QueryRecords(context.Context, *QueryRecordsRequest) (*QueryRecordsResult, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method QueryRecords not implemented")
 }
 func (UnimplementedServiceServer) CountRecords(context.Context, *QueryRecordsRequest) (*CountResult, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CountRecords not implemented")
 }
+func (UnimplementedServiceServer) TruncateLog(context.Context, *TruncateLogRequest) (*TruncateLogResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TruncateLog not implemented")
+}
+func (UnimplementedServiceServer) GetLogStats(context.Context, *GetLogStatsRequest) (*LogStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLogStats not implemented")
+}
+func (UnimplementedServiceServer) TailRecords(*TailRecordsRequest, Service_TailRecordsServer) error {
+	return status.Errorf(codes.Unimplemented, "method TailRecords not implemented")
+}
 func (UnimplementedServiceServer) mustEmbedUnimplementedServiceServer() {}
 
 // UnsafeServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -216,6 +432,42 @@ func _Service_UpdateLog_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Service_PatchLogTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PatchLogTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).PatchLogTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Service_PatchLogTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).PatchLogTags(ctx, req.(*PatchLogTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_BulkPatchLogTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkPatchLogTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).BulkPatchLogTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Service_BulkPatchLogTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).BulkPatchLogTags(ctx, req.(*BulkPatchLogTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Service_QueryLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(QueryLogsRequest)
 	if err := dec(in); err != nil {
@@ -234,6 +486,24 @@ func _Service_QueryLogs_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Service_GetTagFacets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTagFacetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).GetTagFacets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Service_GetTagFacets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).GetTagFacets(ctx, req.(*GetTagFacetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Service_DeleteLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteLogsRequest)
 	if err := dec(in); err != nil {
@@ -252,6 +522,24 @@ func _Service_DeleteLogs_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Service_UndeleteLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UndeleteLogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).UndeleteLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Service_UndeleteLogs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).UndeleteLogs(ctx, req.(*UndeleteLogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Service_AppendRecords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AppendRecordsRequest)
 	if err := dec(in); err != nil {
@@ -270,6 +558,50 @@ func _Service_AppendRecords_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Service_AppendRecordsStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ServiceServer).AppendRecordsStream(&serviceAppendRecordsStreamServer{stream})
+}
+
+type Service_AppendRecordsStreamServer interface {
+	Send(*AppendRecordsAck) error
+	Recv() (*AppendRecordsRequest, error)
+	grpc.ServerStream
+}
+
+type serviceAppendRecordsStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *serviceAppendRecordsStreamServer) Send(m *AppendRecordsAck) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *serviceAppendRecordsStreamServer) Recv() (*AppendRecordsRequest, error) {
+	m := new(AppendRecordsRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Service_AppendRecordsBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AppendRecordsBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).AppendRecordsBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Service_AppendRecordsBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).AppendRecordsBatch(ctx, req.(*AppendRecordsBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Service_QueryRecords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(QueryRecordsRequest)
 	if err := dec(in); err != nil {
@@ -306,6 +638,63 @@ func _Service_CountRecords_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Service_TruncateLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TruncateLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).TruncateLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Service_TruncateLog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).TruncateLog(ctx, req.(*TruncateLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_GetLogStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLogStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).GetLogStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Service_GetLogStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).GetLogStats(ctx, req.(*GetLogStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_TailRecords_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TailRecordsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ServiceServer).TailRecords(m, &serviceTailRecordsServer{stream})
+}
+
+type Service_TailRecordsServer interface {
+	Send(*TailRecordsResult) error
+	grpc.ServerStream
+}
+
+type serviceTailRecordsServer struct {
+	grpc.ServerStream
+}
+
+func (x *serviceTailRecordsServer) Send(m *TailRecordsResult) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // Service_ServiceDesc is the grpc.ServiceDesc for Service service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -321,18 +710,38 @@ var Service_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateLog",
 			Handler:    _Service_UpdateLog_Handler,
 		},
+		{
+			MethodName: "PatchLogTags",
+			Handler:    _Service_PatchLogTags_Handler,
+		},
+		{
+			MethodName: "BulkPatchLogTags",
+			Handler:    _Service_BulkPatchLogTags_Handler,
+		},
 		{
 			MethodName: "QueryLogs",
 			Handler:    _Service_QueryLogs_Handler,
 		},
+		{
+			MethodName: "GetTagFacets",
+			Handler:    _Service_GetTagFacets_Handler,
+		},
 		{
 			MethodName: "DeleteLogs",
 			Handler:    _Service_DeleteLogs_Handler,
 		},
+		{
+			MethodName: "UndeleteLogs",
+			Handler:    _Service_UndeleteLogs_Handler,
+		},
 		{
 			MethodName: "AppendRecords",
 			Handler:    _Service_AppendRecords_Handler,
 		},
+		{
+			MethodName: "AppendRecordsBatch",
+			Handler:    _Service_AppendRecordsBatch_Handler,
+		},
 		{
 			MethodName: "QueryRecords",
 			Handler:    _Service_QueryRecords_Handler,
@@ -341,7 +750,27 @@ var Service_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CountRecords",
 			Handler:    _Service_CountRecords_Handler,
 		},
+		{
+			MethodName: "TruncateLog",
+			Handler:    _Service_TruncateLog_Handler,
+		},
+		{
+			MethodName: "GetLogStats",
+			Handler:    _Service_GetLogStats_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "AppendRecordsStream",
+			Handler:       _Service_AppendRecordsStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "TailRecords",
+			Handler:       _Service_TailRecords_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "solaris.proto",
 }
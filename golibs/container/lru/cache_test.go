@@ -216,6 +216,40 @@ func TestCache_Remove(t *testing.T) {
 	assert.Equal(t, []int{5}, deleted)
 }
 
+func TestCache_RemoveInflight(t *testing.T) {
+	started := make(chan struct{})
+	proceed := make(chan struct{})
+	calls := 0
+	f := func(k int) (int, error) {
+		calls++
+		if calls == 1 {
+			close(started)
+			<-proceed
+		}
+		return calls, nil
+	}
+	p, err := NewCache[int, int](20, f, nil)
+	assert.Nil(t, err)
+
+	done := make(chan int)
+	go func() {
+		v, _ := p.GetOrCreate(1)
+		done <- v
+	}()
+	<-started
+	// the value being created is outdated after the removal
+	assert.False(t, p.Remove(1))
+	close(proceed)
+	assert.Equal(t, 1, <-done)
+
+	v, err := p.GetOrCreate(1)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, v)
+	v, err = p.GetOrCreate(1)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, v)
+}
+
 func TestCache_Clear(t *testing.T) {
 	f := func(k int) (int, error) {
 		return k, nil
@@ -28,6 +28,7 @@ type ECache[PK any, K comparable, V any] struct {
 	maxSize        int
 	items          *iterable.Map[K, pair[PK, V]]
 	inflight       map[K]chan struct{}
+	removed        map[K]struct{}
 	createNewF     CreatePoolElemF[PK, V]
 	onDeleteF      OnDeleteElemF[PK, V]
 	mapToInnerKeyF MapToInnerKeyF[PK, K]
@@ -55,6 +56,7 @@ func NewECache[PK any, K comparable, V any](maxSize int, toComparableF MapToInne
 	p := new(ECache[PK, K, V])
 	p.items = iterable.NewMap[K, pair[PK, V]]()
 	p.inflight = make(map[K]chan struct{})
+	p.removed = make(map[K]struct{})
 	p.maxSize = maxSize
 	p.createNewF = createNewF
 	p.onDeleteF = onDeleteF
@@ -93,7 +95,10 @@ func (p *ECache[PK, K, V]) GetOrCreate(pk PK) (V, error) {
 		p.lock.Lock()
 		close(ch)
 		delete(p.inflight, k)
-		if err == nil {
+		// the element was removed while it was being created, so the value may be outdated and is not cached
+		_, removed := p.removed[k]
+		delete(p.removed, k)
+		if err == nil && !removed {
 			p.items.Add(k, pair[PK, V]{pk, v})
 			if p.maxSize < p.items.Len() {
 				k, _ := p.items.First()
@@ -111,12 +116,16 @@ func (p *ECache[PK, K, V]) GetOrCreate(pk PK) (V, error) {
 }
 
 // Remove deletes the element by key k. It returns true if the element
-// was in the collection and false if it was not found
+// was in the collection and false if it was not found. If the element is being
+// created at the moment, the created value will not be added to the collection.
 func (p *ECache[PK, K, V]) Remove(pk PK) bool {
 	k := p.mapToInnerKeyF(pk)
 	p.lock.Lock()
 	defer p.lock.Unlock()
 
+	if _, ok := p.inflight[k]; ok {
+		p.removed[k] = struct{}{}
+	}
 	v, ok := p.items.Get(k)
 	if !ok {
 		return false
@@ -181,6 +181,37 @@ func (r *ReleasableCache[K, V]) Release(rlsbl *Releasable[V]) {
 	return
 }
 
+// Remove deletes the object with the key k from the cache, the onDeleteF is called for the object. The
+// function returns errors.ErrConflict if the object is borrowed or is being created at the moment.
+// If the object is not in the cache, the function does nothing and returns nil.
+func (r *ReleasableCache[K, V]) Remove(k K) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.closed {
+		return errors.ErrClosed
+	}
+	if _, ok := r.inflight[k]; ok {
+		return fmt.Errorf("the object for key=%v is being created: %w", k, errors.ErrConflict)
+	}
+	rh, ok := r.allKnown[k]
+	if !ok {
+		return nil
+	}
+	if rh.refCounter > 0 {
+		return fmt.Errorf("the object for key=%v is borrowed, refCounter=%d: %w", k, rh.refCounter, errors.ErrConflict)
+	}
+	r.lruCache.Remove(k)
+	delete(r.allKnown, k)
+	if r.onDeleteF != nil {
+		r.onDeleteF(k, rh.value)
+	}
+	if r.waiter != nil {
+		close(r.waiter)
+		r.waiter = nil
+	}
+	return nil
+}
+
 // Close removes all not borrowed objects. The objects that are not released yet will be deleted after the
 // Release() call. After the Close() call the new objects cannot be created
 func (r *ReleasableCache[K, V]) Close() error {
@@ -356,3 +356,35 @@ func TestReleasableCache_Close1(t *testing.T) {
 	_, err = p.GetOrCreate(context.Background(), 1)
 	assert.True(t, errors.Is(err, errors.ErrClosed))
 }
+
+func TestReleasableCache_Remove(t *testing.T) {
+	m := make(map[int]int)
+	df := func(k, v int) {
+		m[k] = v
+	}
+	f := func(_ context.Context, k int) (int, error) {
+		return k, nil
+	}
+	p, err := NewReleasableCache[int, int](2, f, df)
+	assert.Nil(t, err)
+	assert.Nil(t, p.Remove(1))
+
+	rl, err := p.GetOrCreate(context.Background(), 1)
+	assert.Nil(t, err)
+	assert.True(t, errors.Is(p.Remove(1), errors.ErrConflict))
+	assert.Equal(t, 0, len(m))
+
+	p.Release(&rl)
+	assert.Nil(t, p.Remove(1))
+	assert.Equal(t, 1, m[1])
+	assert.Equal(t, 0, len(p.allKnown))
+	assert.Equal(t, 0, p.lruCache.Len())
+
+	rl, err = p.GetOrCreate(context.Background(), 1)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, rl.Value())
+	p.Release(&rl)
+
+	p.Close()
+	assert.True(t, errors.Is(p.Remove(1), errors.ErrClosed))
+}
@@ -295,6 +295,12 @@ func (f *Format) GetFormat() string {
 	return f.frmt
 }
 
+// HasLocation returns true if the format contains the time zone (the offset or the name), so
+// the parsed time is in the time zone of the value, otherwise it is in UTC
+func (f *Format) HasLocation() bool {
+	return f.hasLocation
+}
+
 func adjustYear(tm time.Time) time.Time {
 	now := time.Now()
 	year := now.Year()
@@ -23,8 +23,7 @@ import (
 	"github.com/solarisdb/solaris/golibs/logging"
 	"github.com/solarisdb/solaris/golibs/ulidutils"
 	"github.com/solarisdb/solaris/pkg/storage"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	"io"
 )
 
 // Service implements the grpc public API (see solaris.ServiceServer)
@@ -32,11 +31,16 @@ type Service struct {
 	solaris.UnimplementedServiceServer
 	logger logging.Logger
 
-	LogsStorage storage.Logs `inject:""`
-	LogStorage  storage.Log  `inject:""`
+	LogsStorage storage.Logs      `inject:""`
+	LogStorage  storage.Log       `inject:""`
+	Notifier    *storage.Notifier `inject:""`
 }
 
-const maxLogsToMerge = 1000
+const (
+	maxLogsToMerge = 1000
+	// tailBatchSize is the maximum number of records of one log sent in one TailRecordsResult
+	tailBatchSize = 1000
+)
 
 var _ solaris.ServiceServer = (*Service)(nil)
 
@@ -64,10 +68,43 @@ func (s *Service) UpdateLog(ctx context.Context, log *solaris.Log) (*solaris.Log
 	return res, errors.GRPCWrap(err)
 }
 
+func (s *Service) PatchLogTags(ctx context.Context, request *solaris.PatchLogTagsRequest) (*solaris.Log, error) {
+	s.logger.Infof("patching log tags: %v", request)
+	res, err := s.LogsStorage.PatchLogTags(ctx, storage.PatchLogTagsRequest{ID: request.LogID, Set: request.Set,
+		Remove: request.Remove, Version: request.Version})
+	if err != nil {
+		s.logger.Warnf("could not patch log tags for the request=%v: %v", request, err)
+	}
+	return res, errors.GRPCWrap(err)
+}
+
+func (s *Service) BulkPatchLogTags(ctx context.Context, request *solaris.BulkPatchLogTagsRequest) (*solaris.BulkPatchLogTagsResult, error) {
+	s.logger.Infof("patching logs tags: %v", request)
+	res, err := s.LogsStorage.BulkPatchLogTags(ctx, storage.BulkPatchLogTagsRequest{Condition: request.Condition,
+		Set: request.Set, Remove: request.Remove})
+	if err != nil {
+		s.logger.Warnf("could not patch logs tags for the request=%v: %v", request, err)
+	} else {
+		s.logger.Infof("%d logs patched for request=%v", len(res.PatchedIDs), request)
+	}
+	return res, errors.GRPCWrap(err)
+}
+
 func (s *Service) QueryLogs(ctx context.Context, request *solaris.QueryLogsRequest) (*solaris.QueryLogsResult, error) {
-	res, err := s.LogsStorage.QueryLogs(ctx, storage.QueryLogsRequest{Condition: request.Condition, Page: request.PageID, Limit: request.Limit})
+	res, err := s.LogsStorage.QueryLogs(ctx, storage.QueryLogsRequest{Condition: request.Condition, Page: request.PageID,
+		Limit: request.Limit, SortBy: request.SortBy, Descending: request.Descending, SkipTotal: request.SkipTotal})
 	if err != nil {
 		s.logger.Warnf("could not query=%v: %v", request, err)
+		return nil, errors.GRPCWrap(err)
+	}
+	return res, nil
+}
+
+func (s *Service) GetTagFacets(ctx context.Context, request *solaris.GetTagFacetsRequest) (*solaris.GetTagFacetsResult, error) {
+	res, err := s.LogsStorage.GetTagFacets(ctx, storage.TagFacetsRequest{Condition: request.Condition, Tag: request.Tag,
+		Limit: request.Limit})
+	if err != nil {
+		s.logger.Warnf("could not get tag facets for the request=%v: %v", request, err)
 	}
 	return res, errors.GRPCWrap(err)
 }
@@ -83,6 +120,17 @@ func (s *Service) DeleteLogs(ctx context.Context, request *solaris.DeleteLogsReq
 	return res, errors.GRPCWrap(err)
 }
 
+func (s *Service) UndeleteLogs(ctx context.Context, request *solaris.UndeleteLogsRequest) (*solaris.UndeleteLogsResult, error) {
+	s.logger.Infof("undelete logs: %v", request)
+	res, err := s.LogsStorage.UndeleteLogs(ctx, storage.UndeleteLogsRequest{Condition: request.Condition, IDs: request.LogIDs})
+	if err != nil {
+		s.logger.Warnf("could not undelete logs for the request=%v: %v", request, err)
+	} else {
+		s.logger.Infof("%d logs restored for request=%v", len(res.UndeletedIDs), request)
+	}
+	return res, errors.GRPCWrap(err)
+}
+
 func (s *Service) AppendRecords(ctx context.Context, request *solaris.AppendRecordsRequest) (*solaris.AppendRecordsResult, error) {
 	_, err := s.LogsStorage.GetLogByID(ctx, request.LogID)
 	if err != nil {
@@ -95,18 +143,64 @@ func (s *Service) AppendRecords(ctx context.Context, request *solaris.AppendReco
 	return res, errors.GRPCWrap(err)
 }
 
+func (s *Service) AppendRecordsBatch(ctx context.Context, request *solaris.AppendRecordsBatchRequest) (*solaris.AppendRecordsBatchResult, error) {
+	for _, r := range request.Requests {
+		if _, err := s.LogsStorage.GetLogByID(ctx, r.LogID); err != nil {
+			return nil, errors.GRPCWrap(err)
+		}
+	}
+	res, err := s.LogStorage.AppendRecordsBatch(ctx, request.Requests)
+	if err != nil {
+		s.logger.Warnf("could not append records batch for %d logs: %v", len(request.Requests), err)
+		return nil, errors.GRPCWrap(err)
+	}
+	return &solaris.AppendRecordsBatchResult{Results: res}, nil
+}
+
+// AppendRecordsStream receives the append requests from the stream and acknowledges every request after
+// its records are persisted. The requests are processed one by one in the order they are received.
+func (s *Service) AppendRecordsStream(stream solaris.Service_AppendRecordsStreamServer) error {
+	ctx := stream.Context()
+	// known contains the logs already checked for existence in the stream
+	known := make(map[string]struct{})
+	var seqNo int64
+	for {
+		request, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		seqNo++
+		if _, ok := known[request.LogID]; !ok {
+			if _, err := s.LogsStorage.GetLogByID(ctx, request.LogID); err != nil {
+				return errors.GRPCWrap(err)
+			}
+			known[request.LogID] = struct{}{}
+		}
+		res, err := s.LogStorage.AppendRecords(ctx, request)
+		if err != nil {
+			s.logger.Warnf("could not append records to logID=%s, seqNo=%d: %v", request.LogID, seqNo, err)
+			return errors.GRPCWrap(err)
+		}
+		if err := stream.Send(&solaris.AppendRecordsAck{SeqNo: seqNo, LogID: request.LogID, Result: res}); err != nil {
+			return err
+		}
+	}
+}
+
 func (s *Service) QueryRecords(ctx context.Context, request *solaris.QueryRecordsRequest) (*solaris.QueryRecordsResult, error) {
 	logIDs := request.LogIDs
-	if len(logIDs) == 0 {
+	// no logs are selected, if neither the logs IDs nor the logs condition is provided
+	if len(logIDs) == 0 && len(request.LogsCondition) > 0 {
 		// requesting maxLogsToMerge+1 to be sure that if we have more than the maximum, will interrupt the procedure
-		qr, err := s.LogsStorage.QueryLogs(ctx, storage.QueryLogsRequest{Condition: request.LogsCondition, Limit: int64(maxLogsToMerge + 1)})
+		qr, err := s.LogsStorage.QueryLogs(ctx, storage.QueryLogsRequest{Condition: request.LogsCondition, Limit: int64(maxLogsToMerge + 1),
+			SkipTotal: true})
 		if err != nil {
 			return nil, errors.GRPCWrap(err)
 		}
-		logIDs := make([]string, len(qr.Logs))
-		for i, l := range qr.Logs {
-			logIDs[i] = l.ID
-		}
+		logIDs = toLogIDs(qr.Logs)
 	}
 	if len(logIDs) > maxLogsToMerge {
 		return nil, errors.GRPCWrap(fmt.Errorf("could not merge more than %d logs together: %w", maxLogsToMerge, errors.ErrExhausted))
@@ -160,6 +254,169 @@ func (s *Service) QueryRecords(ctx context.Context, request *solaris.QueryRecord
 	return &solaris.QueryRecordsResult{Records: res, NextPageID: nextID}, errors.GRPCWrap(err)
 }
 
-func (s *Service) CountRecords(context.Context, *solaris.QueryRecordsRequest) (*solaris.CountResult, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CountRecords not implemented")
+func (s *Service) CountRecords(ctx context.Context, request *solaris.QueryRecordsRequest) (*solaris.CountResult, error) {
+	logIDs := request.LogIDs
+	more := false
+	if len(logIDs) == 0 && len(request.LogsCondition) > 0 {
+		// requesting maxLogsToMerge+1 to be sure that if we have more than the maximum, will interrupt the procedure
+		qr, err := s.LogsStorage.QueryLogs(ctx, storage.QueryLogsRequest{Condition: request.LogsCondition, Limit: int64(maxLogsToMerge + 1),
+			SkipTotal: true})
+		if err != nil {
+			return nil, errors.GRPCWrap(err)
+		}
+		logIDs = toLogIDs(qr.Logs)
+		// the storage may return fewer logs than requested, but the next page means there are more of them
+		more = len(qr.NextPageID) > 0
+	}
+	if len(logIDs) > maxLogsToMerge || more {
+		return nil, errors.GRPCWrap(fmt.Errorf("could not count records of more than %d logs together: %w", maxLogsToMerge, errors.ErrExhausted))
+	}
+
+	var total int64
+	for _, lid := range logIDs {
+		n, err := s.LogStorage.CountRecords(ctx, storage.QueryRecordsRequest{Condition: request.Condition,
+			LogID: lid, Descending: request.Descending, StartID: request.StartRecordID})
+		if err != nil {
+			s.logger.Warnf("could not count records for logID=%s, request=%v: %v", lid, request, err)
+			return nil, errors.GRPCWrap(err)
+		}
+		total += n
+	}
+	return &solaris.CountResult{Total: total}, nil
+}
+
+func (s *Service) TruncateLog(ctx context.Context, request *solaris.TruncateLogRequest) (*solaris.TruncateLogResult, error) {
+	_, err := s.LogsStorage.GetLogByID(ctx, request.LogID)
+	if err != nil {
+		return nil, errors.GRPCWrap(err)
+	}
+	res, err := s.LogStorage.TruncateLog(ctx, request)
+	if err != nil {
+		s.logger.Warnf("could not truncate the logID=%s for the request=%v: %v", request.LogID, request, err)
+	} else {
+		s.logger.Infof("%d records removed from the logID=%s by the request=%v", res.Removed, request.LogID, request)
+	}
+	return res, errors.GRPCWrap(err)
+}
+
+func (s *Service) GetLogStats(ctx context.Context, request *solaris.GetLogStatsRequest) (*solaris.LogStats, error) {
+	_, err := s.LogsStorage.GetLogByID(ctx, request.LogID)
+	if err != nil {
+		return nil, errors.GRPCWrap(err)
+	}
+	res, err := s.LogStorage.GetLogStats(ctx, request.LogID)
+	if err != nil {
+		s.logger.Warnf("could not get stats of the logID=%s: %v", request.LogID, err)
+	}
+	return res, errors.GRPCWrap(err)
+}
+
+// TailRecords sends the records of the requested logs starting from the request StartRecordID, and then
+// follows the logs sending the new records as soon as they are committed. The function returns when the
+// stream context is closed or an error happens.
+//
+// Every log is read from its own position, so the records of one log are sent in ascending order,
+// but the records of different logs are not ordered relative to each other. The stream is written
+// synchronously, so a slow client holds the reading, and the changes notifications are coalesced
+// until the client is ready to receive more.
+func (s *Service) TailRecords(request *solaris.TailRecordsRequest, stream solaris.Service_TailRecordsServer) error {
+	ctx := stream.Context()
+	logIDs := request.LogIDs
+	more := false
+	if len(logIDs) == 0 && len(request.LogsCondition) > 0 {
+		qr, err := s.LogsStorage.QueryLogs(ctx, storage.QueryLogsRequest{Condition: request.LogsCondition, Limit: int64(maxLogsToMerge + 1),
+			SkipTotal: true})
+		if err != nil {
+			return errors.GRPCWrap(err)
+		}
+		logIDs = toLogIDs(qr.Logs)
+		// the storage may return fewer logs than requested, but the next page means there are more of them
+		more = len(qr.NextPageID) > 0
+	}
+	if len(logIDs) > maxLogsToMerge || more {
+		return errors.GRPCWrap(fmt.Errorf("could not tail more than %d logs together: %w", maxLogsToMerge, errors.ErrExhausted))
+	}
+
+	// subscribe before the first read, so no record appended after the read is missed
+	sub := s.Notifier.Subscribe(logIDs)
+	defer sub.Close()
+
+	startIDs := make(map[string]string, len(logIDs))
+	for _, lid := range logIDs {
+		startIDs[lid] = request.StartRecordID
+	}
+	// dirty contains the logs that may have records not sent yet
+	dirty := make(map[string]struct{})
+	for {
+		if len(dirty) == 0 {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-sub.C():
+			}
+		}
+		for _, lid := range sub.Changed() {
+			dirty[lid] = struct{}{}
+		}
+		// one batch per log at a time, so a hot log does not hold the others
+		for lid := range dirty {
+			more, err := s.tailLog(stream, lid, request.Condition, startIDs)
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				s.logger.Warnf("could not tail records of logID=%s for the request=%v: %v", lid, request, err)
+				return errors.GRPCWrap(err)
+			}
+			if !more {
+				delete(dirty, lid)
+			}
+		}
+	}
+}
+
+// tailLog reads and sends the next portion of the log records starting from its position in startIDs.
+// The function returns true if there are potentially more records to be sent.
+//
+// If the records condition is provided, the position is advanced past the last record of the log even
+// if the records read don't match the condition, so the not matching records are not scanned again.
+func (s *Service) tailLog(stream solaris.Service_TailRecordsServer, lid, cond string, startIDs map[string]string) (bool, error) {
+	ctx := stream.Context()
+	var lastID string
+	if len(cond) > 0 {
+		// the last record is read before the query, so no record appended after the query is skipped
+		last, _, err := s.LogStorage.QueryRecords(ctx, storage.QueryRecordsRequest{LogID: lid, Descending: true, Limit: 1})
+		if err != nil {
+			return false, err
+		}
+		if len(last) > 0 {
+			lastID = last[0].ID
+		}
+	}
+	res, more, err := s.LogStorage.QueryRecords(ctx, storage.QueryRecordsRequest{Condition: cond,
+		LogID: lid, StartID: startIDs[lid], Limit: tailBatchSize})
+	if err != nil {
+		return false, err
+	}
+	if len(res) > 0 {
+		startIDs[lid] = ulidutils.NextID(res[len(res)-1].ID)
+	}
+	if !more && len(lastID) > 0 {
+		// all the records up to the last one are scanned
+		if nextID := ulidutils.NextID(lastID); nextID > startIDs[lid] {
+			startIDs[lid] = nextID
+		}
+	}
+	if len(res) == 0 {
+		return false, nil
+	}
+	return more, stream.Send(&solaris.TailRecordsResult{Records: res})
+}
+
+func toLogIDs(logs []*solaris.Log) []string {
+	res := make([]string, len(logs))
+	for i, l := range logs {
+		res[i] = l.ID
+	}
+	return res
 }
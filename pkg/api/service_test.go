@@ -0,0 +1,166 @@
+// Copyright 2024 The Solaris Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"github.com/solarisdb/solaris/api/gen/solaris/v1"
+	"github.com/solarisdb/solaris/golibs/errors"
+	"github.com/solarisdb/solaris/golibs/ulidutils"
+	"github.com/solarisdb/solaris/pkg/storage"
+	"github.com/solarisdb/solaris/pkg/storage/buntdb"
+	"github.com/solarisdb/solaris/pkg/storage/chunkfs"
+	"github.com/solarisdb/solaris/pkg/storage/logfs"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"testing"
+	"time"
+)
+
+type (
+	// testTailStream collects the records sent to the tail stream
+	testTailStream struct {
+		solaris.Service_TailRecordsServer
+		ctx  context.Context
+		sent []*solaris.Record
+	}
+
+	// testScansLog remembers the start IDs of the filtered queries
+	testScansLog struct {
+		storage.Log
+		starts []string
+	}
+)
+
+func (ts *testTailStream) Context() context.Context {
+	return ts.ctx
+}
+
+func (ts *testTailStream) Send(res *solaris.TailRecordsResult) error {
+	ts.sent = append(ts.sent, res.Records...)
+	return nil
+}
+
+func (sl *testScansLog) QueryRecords(ctx context.Context, request storage.QueryRecordsRequest) ([]*solaris.Record, bool, error) {
+	if len(request.Condition) > 0 {
+		sl.starts = append(sl.starts, request.StartID)
+	}
+	return sl.Log.QueryRecords(ctx, request)
+}
+
+func TestService_TailLogNotMatching(t *testing.T) {
+	ctx := context.Background()
+	dir, err := os.MkdirTemp("", "TestService_TailLogNotMatching")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	ms := buntdb.NewStorage(buntdb.Config{DBFilePath: ""})
+	assert.Nil(t, ms.Init(ctx))
+	defer ms.Shutdown()
+	p := chunkfs.NewProvider(dir, 1, chunkfs.GetDefaultConfig())
+	defer p.Close()
+	ll := logfs.NewLocalLog(logfs.GetDefaultConfig())
+	ll.LMStorage = ms
+	ll.ChnkProvider = p
+	defer ll.Shutdown()
+
+	log, err := ms.CreateLog(ctx, &solaris.Log{})
+	assert.Nil(t, err)
+	sl := &testScansLog{Log: ll}
+	s := NewService()
+	s.LogStorage = sl
+	stream := &testTailStream{ctx: ctx}
+	startIDs := map[string]string{log.ID: ""}
+	cond := "payload = 'match'"
+
+	for i := 0; i < 3; i++ {
+		res, err := ll.AppendRecords(ctx, &solaris.AppendRecordsRequest{LogID: log.ID, Records: []*solaris.Record{{Payload: []byte("other")}}})
+		assert.Nil(t, err)
+		more, err := s.tailLog(stream, log.ID, cond, startIDs)
+		assert.Nil(t, err)
+		assert.False(t, more)
+		assert.Empty(t, stream.sent)
+		// the not matching record is not scanned again
+		assert.Equal(t, ulidutils.NextID(res.LastID), startIDs[log.ID])
+	}
+	assert.Equal(t, 3, len(sl.starts))
+	assert.True(t, sl.starts[1] > sl.starts[0])
+	assert.True(t, sl.starts[2] > sl.starts[1])
+
+	res, err := ll.AppendRecords(ctx, &solaris.AppendRecordsRequest{LogID: log.ID, Records: []*solaris.Record{{Payload: []byte("other")}, {Payload: []byte("match")}}})
+	assert.Nil(t, err)
+	more, err := s.tailLog(stream, log.ID, cond, startIDs)
+	assert.Nil(t, err)
+	assert.False(t, more)
+	assert.Equal(t, 1, len(stream.sent))
+	assert.Equal(t, res.LastID, stream.sent[0].ID)
+	assert.Equal(t, ulidutils.NextID(res.LastID), startIDs[log.ID])
+}
+
+func TestService_CountRecordsLogsLimit(t *testing.T) {
+	ctx := context.Background()
+	ms := buntdb.NewStorage(buntdb.Config{DBFilePath: ""})
+	assert.Nil(t, ms.Init(ctx))
+	defer ms.Shutdown()
+	lh := storage.NewLogHelper()
+	s := NewService()
+	s.LogsStorage = ms
+	s.LogStorage = lh
+
+	var logIDs []string
+	for i := 0; i <= maxLogsToMerge; i++ {
+		log, err := ms.CreateLog(ctx, &solaris.Log{Tags: map[string]string{"n": fmt.Sprintf("%d", i)}})
+		assert.Nil(t, err)
+		logIDs = append(logIDs, log.ID)
+	}
+	_, err := lh.AppendRecords(ctx, &solaris.AppendRecordsRequest{LogID: logIDs[0], Records: []*solaris.Record{{Payload: []byte("a")}, {Payload: []byte("b")}}})
+	assert.Nil(t, err)
+
+	_, err = s.CountRecords(ctx, &solaris.QueryRecordsRequest{LogsCondition: "tag('n') != ''"})
+	assert.True(t, errors.Is(err, errors.ErrExhausted))
+	_, err = s.CountRecords(ctx, &solaris.QueryRecordsRequest{LogIDs: logIDs})
+	assert.True(t, errors.Is(err, errors.ErrExhausted))
+
+	_, err = ms.DeleteLogs(ctx, storage.DeleteLogsRequest{IDs: logIDs[maxLogsToMerge:], MarkOnly: true})
+	assert.Nil(t, err)
+	cr, err := s.CountRecords(ctx, &solaris.QueryRecordsRequest{LogsCondition: "tag('n') != ''"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), cr.Total)
+	cr, err = s.CountRecords(ctx, &solaris.QueryRecordsRequest{LogIDs: logIDs[:maxLogsToMerge]})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), cr.Total)
+}
+
+func TestService_TailRecordsLogsLimit(t *testing.T) {
+	ctx := context.Background()
+	ms := buntdb.NewStorage(buntdb.Config{DBFilePath: ""})
+	assert.Nil(t, ms.Init(ctx))
+	defer ms.Shutdown()
+	s := NewService()
+	s.LogsStorage = ms
+	s.LogStorage = storage.NewLogHelper()
+	s.Notifier = storage.NewNotifier()
+
+	for i := 0; i <= maxLogsToMerge; i++ {
+		_, err := ms.CreateLog(ctx, &solaris.Log{Tags: map[string]string{"n": fmt.Sprintf("%d", i)}})
+		assert.Nil(t, err)
+	}
+	// the stream is closed by the timeout, if the tail is started
+	tctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	err := s.TailRecords(&solaris.TailRecordsRequest{LogsCondition: "tag('n') != ''"}, &testTailStream{ctx: tctx})
+	assert.True(t, errors.Is(err, errors.ErrExhausted))
+}
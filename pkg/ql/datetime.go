@@ -22,13 +22,15 @@ import (
 	"strconv"
 	"strings"
 	"time"
+	// the time zones database is embedded, so the time zones are known even if the system has no one
+	_ "time/tzdata"
 )
 
 // dateTimeParser allows to parse date-time formats allowed for the date-time points
 var dateTimeParser = datetime.NewParser([]string{
 	"MMM D, YYYY h:mm:ss P",
 	"DDD MMM _D HH:mm:ss YYYY",
-	"DDD MMM _D HH:mm:ss MST YYYY",
+	"DDD MMM _D HH:mm:ss ZZZ YYYY",
 	"DDD MMM DD HH:mm:ss ZZZZ YYYY",
 	"DDDD, YY-MMM-DD HH:mm:ss ZZZ",
 	"DDD, DD MMM YYYY HH:mm:ss ZZZ",
@@ -125,7 +127,8 @@ var dateTimeParser = datetime.NewParser([]string{
 // -<number>(m|h|d]) where m stays for minutes, h for hour[s] and
 // d for day[s] (24 hours)
 // Examples are: '-1.5h' means the timestamp for 1 hour 30 mins ago from
-// the current time
+// the current time. The other durations accepted by parseDuration, e.g. '-10s'
+// or '-1h30m', are allowed as well.
 //
 // there are following "special" are allowed:
 // minute	- a minute ago
@@ -133,20 +136,37 @@ var dateTimeParser = datetime.NewParser([]string{
 // day 		- the 12:00AM of today
 // week 	- the timestamp of Sunday 12:00AM for the current week
 func parseDateTime(dt0 string) (time.Time, error) {
+	return parseDateTimeAt(dt0, time.Now(), nil)
+}
+
+// parseDateTimeAt parses the date-time like parseDateTime does, but the relative and the special forms
+// are calculated for the now time provided. If the loc is not nil, the special forms and the absolute
+// date-time without the time zone are considered in the loc time zone, otherwise the absolute
+// date-time without the time zone is considered in UTC.
+func parseDateTimeAt(dt0 string, now time.Time, loc *time.Location) (time.Time, error) {
 	dt := strings.ToLower(strings.Trim(dt0, " "))
 
-	tm, err := parseRalativeDateTime(dt)
+	tm, err := relativeDateTime(dt, now)
 	if err == nil {
 		return tm, nil
 	}
 
-	tm, err = parseConstantsDateTime(dt)
+	if loc != nil {
+		now = now.In(loc)
+	}
+	tm, err = constantsDateTime(dt, now)
 	if err == nil {
 		return tm, nil
 	}
 
 	tm, fm := dateTimeParser.Parse(cast.StringToByteArray(dt0))
 	if fm != nil {
+		if loc != nil && !fm.HasLocation() {
+			// no time zone in the value, so it is the wall clock of the loc
+			y, mn, d := tm.Date()
+			h, m, s := tm.Clock()
+			tm = time.Date(y, mn, d, h, m, s, tm.Nanosecond(), loc)
+		}
 		return tm, nil
 	}
 
@@ -161,35 +181,67 @@ func parseDateTime(dt0 string) (time.Time, error) {
 
 // parseRalativeDateTime parsing relative date-time
 func parseRalativeDateTime(dt string) (time.Time, error) {
+	return relativeDateTime(dt, time.Now())
+}
+
+// relativeDateTime returns the relative date-time in the form of -<duration> for the now time
+func relativeDateTime(dt string, now time.Time) (time.Time, error) {
 	if len(dt) == 0 || dt[0] != '-' {
 		return time.Time{}, fmt.Errorf("wrong relative format. expecting -<number>(m|h|d), but got %q: %w", dt, errors.ErrInvalid)
 	}
-
-	dim := dt[len(dt)-1]
-	var mult float64
-	switch dim {
-	case 'm':
-		mult = float64(time.Minute)
-	case 'h':
-		mult = float64(time.Hour)
-	case 'd':
-		mult = float64(24 * time.Hour)
-	default:
-		return time.Time{}, fmt.Errorf("unknown dimension %c at %s: %w", dim, dt, errors.ErrInvalid)
+	d, err := parseDuration(dt[1:])
+	if err != nil {
+		return time.Time{}, err
 	}
+	return now.Add(-d), nil
+}
 
-	val, err := strconv.ParseFloat(dt[1:len(dt)-1], 64)
+// parseDuration parses the duration in the form of <number>(m|h|d), where m stays for minutes,
+// h for hour[s] and d for day[s] (24 hours), e.g. '1.5h' or '2d'. The forms accepted by
+// time.ParseDuration, like '10s' or '1h30m', are allowed as well.
+func parseDuration(s string) (time.Duration, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if len(s) == 0 || (s[0] != '.' && (s[0] < '0' || s[0] > '9')) {
+		return 0, fmt.Errorf("wrong duration format. expecting <number>(m|h|d), but got %q: %w", s, errors.ErrInvalid)
+	}
+	if s[len(s)-1] == 'd' {
+		val, err := strconv.ParseFloat(s[:len(s)-1], 64)
+		if err != nil {
+			return 0, fmt.Errorf("could not parse value %s: %w", s[:len(s)-1], errors.ErrInvalid)
+		}
+		return time.Duration(val * float64(24*time.Hour)), nil
+	}
+	d, err := time.ParseDuration(s)
 	if err != nil {
-		return time.Time{}, fmt.Errorf("could not parse value %s: %w", dt[1:len(dt)-1], errors.ErrInvalid)
+		return 0, fmt.Errorf("could not parse duration %q: %w", s, errors.ErrInvalid)
 	}
+	return d, nil
+}
 
-	val = val * mult
-	return time.Now().Add(-time.Duration(val)), nil
+// parseLocation returns the time zone by its name (e.g. 'UTC' or 'Europe/Paris') or by
+// the offset from UTC (e.g. '+03:00' or '-0500')
+func parseLocation(tz string) (*time.Location, error) {
+	tz = strings.TrimSpace(tz)
+	if len(tz) > 0 && (tz[0] == '+' || tz[0] == '-') {
+		for _, layout := range []string{"-07:00", "-0700", "-07"} {
+			if tm, err := time.Parse(layout, tz); err == nil {
+				_, offset := tm.Zone()
+				return time.FixedZone(tz, offset), nil
+			}
+		}
+	} else if loc, err := time.LoadLocation(tz); err == nil && len(tz) > 0 {
+		return loc, nil
+	}
+	return nil, fmt.Errorf("unknown time zone %q: %w", tz, errors.ErrInvalid)
 }
 
 // parseConstantsDateTime allows to convert constant values to the time-point
 func parseConstantsDateTime(dt string) (time.Time, error) {
-	now := time.Now()
+	return constantsDateTime(dt, time.Now())
+}
+
+// constantsDateTime converts the constant value to the time-point for the now time
+func constantsDateTime(dt string, now time.Time) (time.Time, error) {
 	switch dt {
 	case "minute":
 		_, _, s := now.Clock()
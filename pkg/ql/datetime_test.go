@@ -16,6 +16,8 @@ package ql
 
 import (
 	"fmt"
+	"github.com/solarisdb/solaris/golibs/errors"
+	"github.com/stretchr/testify/assert"
 	"math"
 	"testing"
 	"time"
@@ -51,6 +53,81 @@ func TestParseRalativeDateTime(t *testing.T) {
 	testParseRalativeDateTime(t, "-5.5d", time.Hour*12*12)
 }
 
+func TestParseDuration(t *testing.T) {
+	for s, d := range map[string]time.Duration{
+		"15m":    15 * time.Minute,
+		" 1.5H ": 90 * time.Minute,
+		"2d":     48 * time.Hour,
+		".5d":    12 * time.Hour,
+		"10s":    10 * time.Second,
+		"1h30m":  90 * time.Minute,
+		"0":      0,
+	} {
+		res, err := parseDuration(s)
+		assert.Nil(t, err, s)
+		assert.Equal(t, d, res, s)
+	}
+	for _, s := range []string{"", "-1h", "+1h", "h", "1x", "1 h", "d"} {
+		_, err := parseDuration(s)
+		assert.ErrorIs(t, err, errors.ErrInvalid, s)
+	}
+}
+
+func TestParseDateTimeAt(t *testing.T) {
+	now := time.Date(2024, 3, 10, 1, 30, 0, 0, time.UTC)
+	plus3, err := parseLocation("+03:00")
+	assert.Nil(t, err)
+
+	tm, err := parseDateTimeAt("2024-03-01", now, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), tm)
+
+	tm, err = parseDateTimeAt("2024-03-01 10:00:00", now, plus3)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2024, 3, 1, 7, 0, 0, 0, time.UTC), tm.UTC())
+
+	// the time zone of the value is used, if it is specified
+	tm, err = parseDateTimeAt("2024-03-01 10:00:00 -0100", now, plus3)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2024, 3, 1, 11, 0, 0, 0, time.UTC), tm.UTC())
+
+	// the named time zone is the time zone of the value as well
+	tm, err = parseDateTimeAt("Fri Mar  1 10:00:00 UTC 2024", now, plus3)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC), tm.UTC())
+	tm, err = parseDateTimeAt("Fri, 01 Mar 2024 10:00:00 UTC", now, plus3)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC), tm.UTC())
+
+	tm, err = parseDateTimeAt("-1h", now, plus3)
+	assert.Nil(t, err)
+	assert.Equal(t, now.Add(-time.Hour), tm)
+
+	// the day starts at 00:00 in the time zone, which is 2024-03-09 21:00 UTC
+	tm, err = parseDateTimeAt("day", now, plus3)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2024, 3, 9, 21, 0, 0, 0, time.UTC), tm.UTC())
+
+	_, err = parseDateTimeAt("not a date", now, nil)
+	assert.ErrorIs(t, err, errors.ErrInvalid)
+}
+
+func TestParseLocation(t *testing.T) {
+	for tz, offset := range map[string]int{"UTC": 0, "+03:00": 3 * 3600, "-0530": -(5*3600 + 30*60), "+02": 2 * 3600} {
+		loc, err := parseLocation(tz)
+		assert.Nil(t, err, tz)
+		_, off := time.Date(2024, 1, 1, 0, 0, 0, 0, loc).Zone()
+		assert.Equal(t, offset, off, tz)
+	}
+	loc, err := parseLocation("Europe/Paris")
+	assert.Nil(t, err)
+	assert.Equal(t, "Europe/Paris", loc.String())
+	for _, tz := range []string{"", "Mars/Olympus", "+3:00", "+25:00"} {
+		_, err = parseLocation(tz)
+		assert.ErrorIs(t, err, errors.ErrInvalid, tz)
+	}
+}
+
 func TestParseQLDateTime(t *testing.T) {
 	testParseLqlDateTime(t, " minute ", time.Minute*2, false)
 	testParseLqlDateTime(t, " HOUR ", time.Hour*2, false)
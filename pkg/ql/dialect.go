@@ -17,6 +17,7 @@ package ql
 import (
 	"fmt"
 	"github.com/solarisdb/solaris/api/gen/solaris/v1"
+	"github.com/solarisdb/solaris/golibs/cast"
 	"github.com/solarisdb/solaris/golibs/errors"
 	"time"
 )
@@ -67,9 +68,10 @@ const (
 	VTTime    ValueType = 2
 	VTBool    ValueType = 3
 	VTStrings ValueType = 4
+	VTNumber  ValueType = 5
 )
 
-var typeNames = []string{"unknown", "string", "time", "bool", "strings"}
+var typeNames = []string{"unknown", "string", "time", "bool", "strings", "number"}
 
 var (
 	LogsCondDialect = Dialect[*solaris.Log]{
@@ -80,16 +82,15 @@ var (
 			},
 			Type: VTString,
 		},
+		NumberParamID: { // numbers are rvalues only
+			Flags:  PfRValue | PfComparable | PfConstValue,
+			ValueF: numberValueF[*solaris.Log],
+			Type:   VTNumber,
+		},
 		ArrayParamID: { // arrays are rvalues only
-			Flags: PfRValue | PfConstValue,
-			ValueF: func(p *Param, _ *solaris.Log) (any, error) {
-				var strArr []string
-				for _, elem := range p.Array {
-					strArr = append(strArr, elem.Value())
-				}
-				return strArr, nil
-			},
-			Type: VTStrings,
+			Flags:  PfRValue | PfConstValue,
+			ValueF: arrayValueF[*solaris.Log],
+			Type:   VTStrings,
 		},
 		"logID": {
 			Flags: PfLValue | PfComparable | PfInLike,
@@ -98,6 +99,13 @@ var (
 			},
 			Type: VTString,
 		},
+		"records": { // records is the number of records in the log
+			Flags: PfLValue | PfComparable | PfInLike,
+			ValueF: func(p *Param, log *solaris.Log) (any, error) {
+				return float64(log.Records), nil
+			},
+			Type: VTNumber,
+		},
 		"tag": { // tag function is written the way -> 'tag("abc") in ["1", "2", "3"]' or 'tag("t1") = "aaa"'
 			Flags: PfLValue | PfComparable | PfRValue | PfInLike,
 			CheckF: func(p *Param) error {
@@ -121,7 +129,22 @@ var (
 			Type: VTString,
 		},
 	}
-	RecordsCondDialect = Dialect[*solaris.Record]{
+	RecordsCondDialect = newRecordsCondDialect(nil, time.Now)
+)
+
+// NewRecordsCondDialect returns the RecordsCondDialect, which parses the record payload once for all
+// the json() functions of the expression. The ExprF built with the dialect must not be called concurrently.
+// The now() function of the dialect returns the time the dialect is created, so the expressions evaluated
+// with the dialect (e.g. the ExprF and the ctime intervals) refer to the same time.
+func NewRecordsCondDialect() Dialect[*solaris.Record] {
+	now := time.Now()
+	return newRecordsCondDialect(new(jsonCache), func() time.Time { return now })
+}
+
+// newRecordsCondDialect returns the records dialect, the jc is the cache of the json() functions, it may be nil.
+// The nowF returns the current time for the now() function.
+func newRecordsCondDialect(jc *jsonCache, nowF func() time.Time) Dialect[*solaris.Record] {
+	d := Dialect[*solaris.Record]{
 		StringParamID: { // strings are rvalues only
 			Flags: PfRValue | PfComparable | PfConstValue,
 			ValueF: func(p *Param, _ *solaris.Record) (any, error) {
@@ -129,6 +152,30 @@ var (
 			},
 			Type: VTString,
 		},
+		NumberParamID: { // numbers are rvalues only
+			Flags:  PfRValue | PfComparable | PfConstValue,
+			ValueF: numberValueF[*solaris.Record],
+			Type:   VTNumber,
+		},
+		ArrayParamID: { // arrays are rvalues only
+			Flags:  PfRValue | PfConstValue,
+			ValueF: arrayValueF[*solaris.Record],
+			Type:   VTStrings,
+		},
+		"payload": { // payload is the record payload as a string
+			Flags: PfLValue | PfComparable | PfInLike,
+			ValueF: func(p *Param, r *solaris.Record) (any, error) {
+				// the payload is not copied, the value must not be kept after the evaluation
+				return cast.ByteArrayToString(r.Payload), nil
+			},
+			Type: VTString,
+		},
+		"json": { // json function returns the value of the JSON payload element -> 'json("$.user.id") = "u42"'
+			Flags:  PfLValue | PfComparable | PfRValue | PfInLike,
+			CheckF: checkJSONF,
+			ValueF: jsonValueF(jc),
+			Type:   VTString,
+		},
 		"ctime": {
 			Flags: PfLValue | PfComparable,
 			ValueF: func(p *Param, r *solaris.Record) (any, error) {
@@ -140,7 +187,127 @@ var (
 			Type: VTTime,
 		},
 	}
-)
+	d["contains"] = containsDialect(d)
+	d["now"] = nowDialect[*solaris.Record](nowF)
+	d["date"] = dateDialect[*solaris.Record](nowF)
+	return d
+}
+
+// nowDialect returns the ParamDialect of the now() function, which returns the current time provided by the nowF
+func nowDialect[T any](nowF func() time.Time) ParamDialect[T] {
+	return ParamDialect[T]{
+		Flags: PfRValue | PfComparable | PfConstValue,
+		CheckF: func(p *Param) error {
+			if p.Function == nil {
+				return fmt.Errorf("now must be a function: %w", errors.ErrInvalid)
+			}
+			if len(p.Function.Params) != 0 {
+				return fmt.Errorf("now() function expects no parameters: %w", errors.ErrInvalid)
+			}
+			return nil
+		},
+		ValueF: func(p *Param, _ T) (any, error) {
+			return nowF(), nil
+		},
+		Type: VTTime,
+	}
+}
+
+// dateDialect returns the ParamDialect of the date(<date-time>[, <time zone>]) function, which returns the
+// date-time parsed by parseDateTimeAt for the current time provided by the nowF -> 'date("2024-03-01", "UTC")'
+func dateDialect[T any](nowF func() time.Time) ParamDialect[T] {
+	return ParamDialect[T]{
+		Flags: PfRValue | PfComparable | PfConstValue,
+		CheckF: func(p *Param) error {
+			if p.Function == nil {
+				return fmt.Errorf("date must be a function: %w", errors.ErrInvalid)
+			}
+			if len(p.Function.Params) != 1 && len(p.Function.Params) != 2 {
+				return fmt.Errorf("date() function expects the date-time and optional time zone parameters: %w", errors.ErrInvalid)
+			}
+			for _, fp := range p.Function.Params {
+				if fp.ID() != StringParamID {
+					return fmt.Errorf("date() function expects the string parameters: %w", errors.ErrInvalid)
+				}
+			}
+			return nil
+		},
+		ValueF: func(p *Param, _ T) (any, error) {
+			var loc *time.Location
+			if len(p.Function.Params) == 2 {
+				var err error
+				if loc, err = parseLocation(p.Function.Params[1].Name(true)); err != nil {
+					return nil, err
+				}
+			}
+			return parseDateTimeAt(p.Function.Params[0].Name(true), nowF(), loc)
+		},
+		Type: VTTime,
+	}
+}
+
+// containsDialect returns the ParamDialect of the contains(<value>, <substring>) function, which checks whether
+// the string value contains the substring ignoring the case. The value is calculated by the dialect d.
+func containsDialect[T any](d Dialect[T]) ParamDialect[T] {
+	return ParamDialect[T]{
+		Flags: PfLValue | PfNop,
+		CheckF: func(p *Param) error {
+			if p.Function == nil {
+				return fmt.Errorf("contains must be a function: %w", errors.ErrInvalid)
+			}
+			if len(p.Function.Params) != 2 {
+				return fmt.Errorf("contains() function expects two parameters - the value and the substring: %w", errors.ErrInvalid)
+			}
+			if p.Function.Params[1].ID() != StringParamID {
+				return fmt.Errorf("contains() function expects the substring (string) as the second parameter: %w", errors.ErrInvalid)
+			}
+			vp := p.Function.Params[0]
+			vd, ok := d[vp.ID()]
+			if !ok {
+				return fmt.Errorf("unknown parameter %s of contains(): %w", vp.Name(false), errors.ErrInvalid)
+			}
+			if vd.Type != VTString {
+				return fmt.Errorf("contains() function expects a string value as the first parameter: %w", errors.ErrInvalid)
+			}
+			return vd.check(vp)
+		},
+		ValueF: func(p *Param, t T) (any, error) {
+			vp := p.Function.Params[0]
+			v, err := d[vp.ID()].ValueF(vp, t)
+			if err != nil {
+				return false, err
+			}
+			return containsFold(v.(string), *p.Function.Params[1].Const.String), nil
+		},
+		Type: VTBool,
+	}
+}
+
+// checkJSONF checks the json() function parameters
+func checkJSONF(p *Param) error {
+	if p.Function == nil {
+		return fmt.Errorf("json must be a function: %w", errors.ErrInvalid)
+	}
+	if len(p.Function.Params) != 1 || p.Function.Params[0].ID() != StringParamID {
+		return fmt.Errorf("json() function expects only one parameter - the path (string) of the element: %w", errors.ErrInvalid)
+	}
+	_, err := parseJSONPath(p.Function.Params[0].Name(true))
+	return err
+}
+
+// arrayValueF returns the values of the array constants as strings
+func arrayValueF[T any](p *Param, _ T) (any, error) {
+	var strArr []string
+	for _, elem := range p.Array {
+		strArr = append(strArr, elem.Value())
+	}
+	return strArr, nil
+}
+
+// numberValueF returns the value of the number constant
+func numberValueF[T any](p *Param, _ T) (any, error) {
+	return *p.Const.Number, nil
+}
 
 // check returns whether the parameter is ok or not. The function is used by the evaluator
 func (pd ParamDialect[T]) check(p *Param) error {
@@ -15,12 +15,16 @@
 package ql
 
 import (
+	"cmp"
 	"fmt"
-	"github.com/solarisdb/solaris/golibs/container"
 	"github.com/solarisdb/solaris/golibs/errors"
+	"regexp"
 	"slices"
+	"strconv"
 	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 )
 
 type (
@@ -171,8 +175,14 @@ func (eb *exprBuilder[T]) buildCond(cn *Condition) (err error) {
 	if d2.Flags&PfNop != 0 {
 		return fmt.Errorf("parameter %s cannot be compared (%s) in the condition: %w", p2.Name(false), cn.Op, errors.ErrInvalid)
 	}
+	if err := d2.check(p2); err != nil {
+		return err
+	}
 
 	op := strings.ToUpper(cn.Op)
+	if len(cn.Durations) > 0 && (op == "IN" || op == "LIKE" || op == "MATCHES") {
+		return fmt.Errorf("the durations are not applicable for the operation %s: %w", cn.Op, errors.ErrInvalid)
+	}
 	switch op {
 	case "<", ">":
 		if d.Flags&PfComparable == 0 && d.Flags&PfGreaterLess == 0 {
@@ -181,11 +191,7 @@ func (eb *exprBuilder[T]) buildCond(cn *Condition) (err error) {
 		if d2.Flags&PfComparable == 0 && d2.Flags&PfGreaterLess == 0 {
 			return fmt.Errorf("the second parameter %s is not applicable for the operation %s: %w", p2.Name(false), cn.Op, errors.ErrInvalid)
 		}
-		p2vf, err := eb.paramDialect2ValueF(d2, p2, &d.Type)
-		if err != nil {
-			return err
-		}
-		return eb.compare(p1vf, p2vf, d.Type, op)
+		return eb.compareParams(p1vf, d, d2, p2, cn.Durations, op)
 	case "<=", ">=", "!=", "=":
 		if d.Flags&PfComparable == 0 {
 			return fmt.Errorf("the first parameter %s is not applicable for the operation %s: %w", p1.Name(false), cn.Op, errors.ErrInvalid)
@@ -193,11 +199,7 @@ func (eb *exprBuilder[T]) buildCond(cn *Condition) (err error) {
 		if d2.Flags&PfComparable == 0 {
 			return fmt.Errorf("the second parameter %s is not applicable for the operation %s: %w", p2.Name(false), cn.Op, errors.ErrInvalid)
 		}
-		p2vf, err := eb.paramDialect2ValueF(d2, p2, &d.Type)
-		if err != nil {
-			return err
-		}
-		return eb.compare(p1vf, p2vf, d.Type, op)
+		return eb.compareParams(p1vf, d, d2, p2, cn.Durations, op)
 	case "IN":
 		if d.Flags&PfInLike == 0 {
 			return fmt.Errorf("the first parameter %s is not applicable for the IN : %w", p1.Name(false), errors.ErrInvalid)
@@ -205,6 +207,19 @@ func (eb *exprBuilder[T]) buildCond(cn *Condition) (err error) {
 		if p2.ID() != ArrayParamID {
 			return fmt.Errorf("the second parameter %s must be an array: %w", p2.Name(false), errors.ErrInvalid)
 		}
+		if d.Type == VTNumber || isNumbers(p2.Array) {
+			// the values are compared as numbers
+			nums, err := numbers(p2.Array)
+			if err != nil {
+				return err
+			}
+			p1vf, err = castValueF(p1vf, d.Type, VTNumber)
+			if err != nil {
+				return err
+			}
+			eb.f = inF(p1vf, nums)
+			return nil
+		}
 		arr, err := d2.ValueF(p2, *new(T))
 		if err != nil {
 			return err
@@ -214,6 +229,9 @@ func (eb *exprBuilder[T]) buildCond(cn *Condition) (err error) {
 		if d.Flags&PfInLike == 0 {
 			return fmt.Errorf("the first parameter %s is not applicable for the LIKE : %w", p1.Name(false), errors.ErrInvalid)
 		}
+		if d.Type != VTString {
+			return fmt.Errorf("the first parameter %s of LIKE must be a string: %w", p1.Name(false), errors.ErrInvalid)
+		}
 		if p2.ID() != StringParamID {
 			return fmt.Errorf("the right value(%s) of LIKE must be a string: %w", p1.Name(false), errors.ErrInvalid)
 		}
@@ -222,12 +240,77 @@ func (eb *exprBuilder[T]) buildCond(cn *Condition) (err error) {
 			return err
 		}
 		return eb.like(p1vf, str.(string))
+	case "MATCHES":
+		if d.Flags&PfInLike == 0 {
+			return fmt.Errorf("the first parameter %s is not applicable for the MATCHES : %w", p1.Name(false), errors.ErrInvalid)
+		}
+		if d.Type != VTString {
+			return fmt.Errorf("the first parameter %s of MATCHES must be a string: %w", p1.Name(false), errors.ErrInvalid)
+		}
+		if p2.ID() != StringParamID {
+			return fmt.Errorf("the right value(%s) of MATCHES must be a string: %w", p2.Name(false), errors.ErrInvalid)
+		}
+		str, err := d2.ValueF(p2, *new(T))
+		if err != nil {
+			return err
+		}
+		return eb.matches(p1vf, str.(string))
 	default:
 		return fmt.Errorf("unknown operation %s: %w", cn.Op, errors.ErrInvalid)
 	}
 	panic("unreacheable")
 }
 
+// compareParams builds the comparison of the p1vf result of the d1 type and the p2 value shifted by the durations ds.
+// The values are compared as numbers if the p2 is a number, otherwise the p2 value is cast to the d1 type.
+func (eb *exprBuilder[T]) compareParams(p1vf valueF[T], d1, d2 ParamDialect[T], p2 *Param, ds []*Duration, op string) error {
+	tp := d1.Type
+	if d2.Type == VTNumber {
+		tp = VTNumber
+	}
+	p1vf, err := castValueF(p1vf, d1.Type, tp)
+	if err != nil {
+		return err
+	}
+	p2vf, err := eb.paramDialect2ValueF(d2, p2, &tp)
+	if err != nil {
+		return err
+	}
+	if p2vf, err = addDurationsF(p2vf, ds, tp); err != nil {
+		return err
+	}
+	return eb.compare(p1vf, p2vf, tp, op)
+}
+
+// addDurationsF wraps the vf, which returns the tp type, to the function adding the durations ds to the vf result.
+// The durations may be added to the time values only.
+func addDurationsF[T any](vf valueF[T], ds []*Duration, tp ValueType) (valueF[T], error) {
+	if len(ds) == 0 {
+		return vf, nil
+	}
+	if tp != VTTime {
+		return nil, fmt.Errorf("the durations may be added to the time values only, but the value is %s: %w", typeNames[tp], errors.ErrInvalid)
+	}
+	var sum time.Duration
+	for _, d := range ds {
+		dur, err := parseDuration(d.Value)
+		if err != nil {
+			return nil, err
+		}
+		if d.Op == "-" {
+			dur = -dur
+		}
+		sum += dur
+	}
+	return func(p *Param, t T) (any, error) {
+		v, err := vf(p, t)
+		if err != nil {
+			return v, err
+		}
+		return v.(time.Time).Add(sum), nil
+	}, nil
+}
+
 // compare builds the ExprF, which will build comparison of vf1 and vf2 results depending on the op
 func (eb *exprBuilder[T]) compare(vf1, vf2 valueF[T], tp ValueType, op string) error {
 	switch tp {
@@ -337,74 +420,220 @@ func (eb *exprBuilder[T]) compare(vf1, vf2 valueF[T], tp ValueType, op string) e
 		default:
 			return fmt.Errorf("unsupport operation %s for the string comparision: %w", op, errors.ErrInvalid)
 		}
+	case VTNumber:
+		cmpF, ok := orderedCmpF[float64](op)
+		if !ok {
+			return fmt.Errorf("unsupport operation %s for the number comparision: %w", op, errors.ErrInvalid)
+		}
+		eb.f = func(t T) bool {
+			v1, err := vf1(nil, t)
+			if err != nil {
+				return false
+			}
+			v2, err := vf2(nil, t)
+			if err != nil {
+				return false
+			}
+			return cmpF(v1.(float64), v2.(float64))
+		}
 	}
 	return nil
 }
 
+// orderedCmpF returns the function comparing two values by the op
+func orderedCmpF[V cmp.Ordered](op string) (func(v1, v2 V) bool, bool) {
+	switch op {
+	case "<":
+		return func(v1, v2 V) bool { return v1 < v2 }, true
+	case ">":
+		return func(v1, v2 V) bool { return v1 > v2 }, true
+	case "<=":
+		return func(v1, v2 V) bool { return v1 <= v2 }, true
+	case ">=":
+		return func(v1, v2 V) bool { return v1 >= v2 }, true
+	case "=":
+		return func(v1, v2 V) bool { return v1 == v2 }, true
+	case "!=":
+		return func(v1, v2 V) bool { return v1 != v2 }, true
+	}
+	return nil, false
+}
+
 // in create the IN operation in eb.f
 func (eb *exprBuilder[T]) in(vf valueF[T], arr []string) error {
+	eb.f = inF(vf, arr)
+	return nil
+}
+
+// inF returns the ExprF, which checks whether the vf result is one of the arr values
+func inF[T any, V comparable](vf valueF[T], arr []V) ExprF[T] {
 	if len(arr) == 0 {
-		eb.f = negative[T]
-		return nil
+		return negative[T]
 	}
-	eb.f = func(t T) bool {
+	return func(t T) bool {
 		v, err := vf(nil, t)
 		if err != nil {
 			return false
 		}
-		return slices.Contains(arr, v.(string))
+		return slices.Contains(arr, v.(V))
 	}
-	return nil
 }
 
-// like creates the LIKE operation in eb.f
-func (eb *exprBuilder[T]) like(vf valueF[T], pat string) error {
-	if pat == "" {
-		eb.f = func(t T) bool {
-			s, err := vf(nil, t)
-			if err != nil {
-				return false
-			}
-			return s.(string) == ""
+// isNumbers returns true if the arr is not empty and all its elements are numbers
+func isNumbers(arr []*Const) bool {
+	for _, c := range arr {
+		if c.Number == nil {
+			return false
 		}
-		return nil
 	}
+	return len(arr) > 0
+}
 
+// numbers returns the values of the arr elements as numbers, the strings are parsed
+func numbers(arr []*Const) ([]float64, error) {
+	res := make([]float64, 0, len(arr))
+	for _, c := range arr {
+		if c.Number != nil {
+			res = append(res, *c.Number)
+			continue
+		}
+		n, err := parseNumber(*c.String)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, n)
+	}
+	return res, nil
+}
+
+// parseNumber parses the s as a number
+func parseNumber(s string) (float64, error) {
+	n, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return 0, fmt.Errorf("could not parse %q as a number: %w", s, errors.ErrInvalid)
+	}
+	return n, nil
+}
+
+// like creates the LIKE operation in eb.f
+func (eb *exprBuilder[T]) like(vf valueF[T], pat string) error {
 	if pat == "%" {
 		eb.f = positive[T]
 		return nil
 	}
 
+	lp := compileLike(pat, '%')
+	eb.f = func(t T) bool {
+		s, err := vf(nil, t)
+		if err != nil {
+			return false
+		}
+		return lp.match(s.(string))
+	}
+	return nil
+}
+
+// matches creates the MATCHES operation in eb.f. The regular expression is compiled once, so the
+// wrong expression is reported while the ExprF is built.
+func (eb *exprBuilder[T]) matches(vf valueF[T], expr string) error {
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return fmt.Errorf("wrong regular expression %q: %v: %w", expr, err, errors.ErrInvalid)
+	}
 	eb.f = func(t T) bool {
 		s, err := vf(nil, t)
 		if err != nil {
 			return false
 		}
-		return like(s.(string), pat, '%')
+		return re.MatchString(s.(string))
 	}
 	return nil
 }
 
+// likePattern is the compiled LIKE pattern. The pattern is split by the wildcards into the parts,
+// which must be met in the string in the order, so the string is matched with no allocations.
+type likePattern []string
+
+func compileLike(pat string, wildcard byte) likePattern {
+	return strings.Split(pat, string(wildcard))
+}
+
+// match returns whether the s matches the pattern
+func (lp likePattern) match(s string) bool {
+	if len(lp) == 1 {
+		return s == lp[0]
+	}
+	if !strings.HasPrefix(s, lp[0]) {
+		return false
+	}
+	s = s[len(lp[0]):]
+	// the leftmost occurrences of the middle parts leave the most of the string for the last part
+	for _, part := range lp[1 : len(lp)-1] {
+		idx := strings.Index(s, part)
+		if idx < 0 {
+			return false
+		}
+		s = s[idx+len(part):]
+	}
+	return strings.HasSuffix(s, lp[len(lp)-1])
+}
+
 func like(s, pat string, wildcard byte) bool {
-	prev := make([]bool, len(s)+1)
-	next := make([]bool, len(s)+1)
-	prev[0] = true
-
-	for i := 0; i < len(pat); i++ {
-		container.SliceFill(next, false)
-		if pat[i] == wildcard {
-			next[0] = prev[0]
-		}
-		for j := 0; j < len(s); j++ {
-			if pat[i] == wildcard {
-				next[j+1] = prev[j] || prev[j+1] || next[j]
-			} else if pat[i] == s[j] {
-				next[j+1] = prev[j]
-			}
+	return compileLike(pat, wildcard).match(s)
+}
+
+// containsFold returns whether the substr is within the s, the case is ignored
+func containsFold(s, substr string) bool {
+	if len(substr) == 0 {
+		return true
+	}
+	r0, _ := utf8.DecodeRuneInString(substr)
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		// the first runes are compared before the whole substr to skip the most of the positions quickly
+		if equalFoldRune(r, r0) && hasPrefixFold(s[i:], substr) {
+			return true
+		}
+		i += size
+	}
+	return false
+}
+
+// hasPrefixFold returns whether the s begins with the prefix, the case is ignored
+func hasPrefixFold(s, prefix string) bool {
+	for _, pr := range prefix {
+		if len(s) == 0 {
+			return false
 		}
-		prev, next = next, prev
+		r, size := utf8.DecodeRuneInString(s)
+		if !equalFoldRune(r, pr) {
+			return false
+		}
+		s = s[size:]
+	}
+	return true
+}
+
+// equalFoldRune returns whether the runes are equal under the Unicode case-folding
+func equalFoldRune(r1, r2 rune) bool {
+	if r1 == r2 {
+		return true
+	}
+	if r1 < utf8.RuneSelf && r2 < utf8.RuneSelf {
+		if 'A' <= r1 && r1 <= 'Z' {
+			r1 += 'a' - 'A'
+		}
+		if 'A' <= r2 && r2 <= 'Z' {
+			r2 += 'a' - 'A'
+		}
+		return r1 == r2
 	}
-	return prev[len(s)]
+	for f := unicode.SimpleFold(r1); f != r1; f = unicode.SimpleFold(f) {
+		if f == r2 {
+			return true
+		}
+	}
+	return false
 }
 
 // paramDialect2ValueF gets the param p and turns it to the valueF function, which will return the type vt. If the vt is nil
@@ -465,6 +694,14 @@ func castValueF[T any](f valueF[T], from, to ValueType) (valueF[T], error) {
 				}
 				return parseDateTime(s.(string))
 			}, nil
+		case VTNumber:
+			return func(p *Param, t T) (any, error) {
+				s, err := f(p, t)
+				if err != nil {
+					return s, err
+				}
+				return parseNumber(s.(string))
+			}, nil
 		}
 	}
 	return f, fmt.Errorf("could not cast value of type %s to %s: %w", typeNames[from], typeNames[to], errors.ErrInvalid)
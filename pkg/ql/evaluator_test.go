@@ -20,6 +20,7 @@ import (
 	"github.com/solarisdb/solaris/golibs/errors"
 	"github.com/solarisdb/solaris/golibs/ulidutils"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"strings"
 	"testing"
 	"time"
@@ -105,6 +106,27 @@ func Test_like(t *testing.T) {
 	assert.True(t, like("abvacefd", "%ac%", '%'))
 	assert.False(t, like("abc", "%d", '%'))
 	assert.False(t, like("abvccefd", "a%ac%d", '%'))
+	assert.True(t, like("", "", '%'))
+	assert.True(t, like("abc", "abc", '%'))
+	assert.True(t, like("aba", "a%a", '%'))
+	assert.True(t, like("abcabc", "%b%%c", '%'))
+	assert.False(t, like("a", "a%a", '%'))
+	assert.False(t, like("abc", "", '%'))
+	assert.False(t, like("abcd", "abc", '%'))
+	assert.False(t, like("abab", "%ba%ba", '%'))
+}
+
+func Test_containsFold(t *testing.T) {
+	assert.True(t, containsFold("abc", ""))
+	assert.True(t, containsFold("The Refund was issued", "refund"))
+	assert.True(t, containsFold("the refund", "REFUND"))
+	assert.False(t, containsFold("Straße", "STRASSE"))
+	assert.True(t, containsFold("ПРИВЕТ мир", "привет"))
+	assert.True(t, containsFold("\u212A", "\u212a"))
+	assert.True(t, containsFold("1 Km", "km"))
+	assert.False(t, containsFold("", "a"))
+	assert.False(t, containsFold("refun", "refund"))
+	assert.False(t, containsFold("refuse", "refund"))
 }
 
 func TestLogCondEval_EvalTrue(t *testing.T) {
@@ -172,3 +194,228 @@ func TestBuildExprF(t *testing.T) {
 	f, err = BuildExprF(expr, testDialect)
 	assert.False(t, f(testRecord{}))
 }
+
+func TestLogCondEval_Numbers(t *testing.T) {
+	log := &solaris.Log{ID: ulidutils.NewID(), Records: 150, Tags: map[string]string{
+		"priority": "10",
+		"ratio":    " 0.1 ",
+		"name":     "abc",
+	}}
+	for _, tc := range []struct {
+		cond string
+		res  bool
+	}{
+		{cond: "tag('priority') > 3", res: true},
+		{cond: "tag('priority') > '3'", res: false},
+		{cond: "tag('priority') >= 10.0", res: true},
+		{cond: "tag('priority') = 1e1", res: true},
+		{cond: "tag('priority') != 10", res: false},
+		{cond: "tag('ratio') = 0.1", res: true},
+		{cond: "tag('ratio') < -1", res: false},
+		{cond: "tag('name') > 0", res: false},
+		{cond: "tag('name') != 0", res: false},
+		{cond: "tag('absent') = 0", res: false},
+		{cond: "tag('priority') IN [1, 10, 100]", res: true},
+		{cond: "tag('priority') IN [1, 100]", res: false},
+		{cond: "tag('priority') IN ['10.0', 1]", res: false},
+		{cond: "tag('priority') IN ['10', 1]", res: true},
+		{cond: "records > 100", res: true},
+		{cond: "records <= 100", res: false},
+		{cond: "records = '150'", res: true},
+		{cond: "records IN ['150', 200]", res: true},
+		{cond: "records > 100 AND tag('priority') < 20", res: true},
+	} {
+		expr, err := Parse(tc.cond)
+		assert.Nil(t, err, tc.cond)
+		eval, err := BuildExprF(expr, LogsCondDialect)
+		assert.Nil(t, err, tc.cond)
+		assert.Equal(t, tc.res, eval(log), tc.cond)
+	}
+
+	for _, cond := range []string{"records > 'abc'", "records like '1%'", "records IN [1, 'b']"} {
+		expr, err := Parse(cond)
+		assert.Nil(t, err, cond)
+		_, err = BuildExprF(expr, LogsCondDialect)
+		assert.ErrorIs(t, err, errors.ErrInvalid, cond)
+	}
+}
+
+func TestRecordCondEval_JSON(t *testing.T) {
+	r := &solaris.Record{ID: ulidutils.NewID(), Payload: []byte(`{"user": {"id": "u42", "age": 30, "admin": true, "roles": ["a", "b"]},
+		"level": "error", "none": null, "msg": "the disk is full"}`)}
+	for _, tc := range []struct {
+		cond string
+		res  bool
+	}{
+		{cond: "json('$.user.id') = 'u42' AND json('$.level') IN ['error','fatal']", res: true},
+		{cond: "json('$.user.id') = 'u43' OR json('$.level') IN ['warn']", res: false},
+		{cond: "json('$.user.age') > 5", res: true},
+		{cond: "json('$.user.age') > '5'", res: false},
+		{cond: "json('$.user.age') IN [10, 30]", res: true},
+		{cond: "json('$.user.admin') = 'true'", res: true},
+		{cond: "json('$.user.roles[1]') = 'b'", res: true},
+		{cond: "json('$.user.roles') = '[\"a\",\"b\"]'", res: true},
+		{cond: "json('$.user.name') = ''", res: false},
+		{cond: "json('$.user.name') != ''", res: false},
+		{cond: "json('$.none') != 'x'", res: false},
+		{cond: "json('$.msg') LIKE '%disk%'", res: true},
+		{cond: "json('$.level') = json('$.level')", res: true},
+		{cond: "payload LIKE '%\"level\": \"error\"%'", res: true},
+		{cond: "payload = 'abc'", res: false},
+	} {
+		expr, err := Parse(tc.cond)
+		assert.Nil(t, err, tc.cond)
+		for _, d := range []Dialect[*solaris.Record]{RecordsCondDialect, NewRecordsCondDialect()} {
+			eval, err := BuildExprF(expr, d)
+			assert.Nil(t, err, tc.cond)
+			assert.Equal(t, tc.res, eval(r), tc.cond)
+		}
+	}
+
+	eval, err := BuildExprF(mustParse(t, "json('level') = 'error'"), NewRecordsCondDialect())
+	assert.Nil(t, err)
+	assert.True(t, eval(r))
+	assert.False(t, eval(&solaris.Record{ID: ulidutils.NewID(), Payload: []byte("not json")}))
+	assert.False(t, eval(&solaris.Record{ID: ulidutils.NewID(), Payload: []byte(`{"level": "info"}`)}))
+
+	for _, cond := range []string{"json() = 'a'", "json('a', 'b') = 'a'", "json(1) = 'a'", "json('$..a') = 'a'", "json = 'a'"} {
+		_, err = BuildExprF(mustParse(t, cond), RecordsCondDialect)
+		assert.ErrorIs(t, err, errors.ErrInvalid, cond)
+	}
+}
+
+func TestRecordCondEval_Payload(t *testing.T) {
+	r := &solaris.Record{ID: ulidutils.NewID(), Payload: []byte("Connection TIMEOUT: the refund request is not sent")}
+	for _, tc := range []struct {
+		cond string
+		res  bool
+	}{
+		{cond: "payload LIKE '%TIMEOUT%'", res: true},
+		{cond: "payload LIKE '%timeout%'", res: false},
+		{cond: "payload LIKE 'Connection%sent'", res: true},
+		{cond: "contains(payload, 'timeout')", res: true},
+		{cond: "contains(payload, 'Refund Request')", res: true},
+		{cond: "contains(payload, 'refunds')", res: false},
+		{cond: "NOT contains(payload, 'refunds') AND contains(payload, '')", res: true},
+		{cond: "contains('abc', 'B')", res: true},
+	} {
+		eval, err := BuildExprF(mustParse(t, tc.cond), NewRecordsCondDialect())
+		assert.Nil(t, err, tc.cond)
+		assert.Equal(t, tc.res, eval(r), tc.cond)
+	}
+
+	r.Payload = []byte(`{"msg": "Payment REFUNDED"}`)
+	eval, err := BuildExprF(mustParse(t, "contains(json('$.msg'), 'refunded')"), NewRecordsCondDialect())
+	assert.Nil(t, err)
+	assert.True(t, eval(r))
+
+	for _, cond := range []string{"contains(payload)", "contains(payload, 1)", "contains(ctime, 'a')", "contains(unknown, 'a')",
+		"contains(json(1), 'a')", "contains(payload, 'a') = 'true'", "contains = 'a'"} {
+		_, err = BuildExprF(mustParse(t, cond), RecordsCondDialect)
+		assert.ErrorIs(t, err, errors.ErrInvalid, cond)
+	}
+}
+
+func TestCondEval_Matches(t *testing.T) {
+	log := &solaris.Log{ID: "01HQ4ZQ0M3", Tags: map[string]string{"env": "prod-eu-1", "name": "api"}}
+	for _, tc := range []struct {
+		cond string
+		res  bool
+	}{
+		{cond: "logID MATCHES '^01HQ'", res: true},
+		{cond: "logID matches '^01HR'", res: false},
+		{cond: "tag('env') MATCHES '^prod-(eu|us)-[0-9]+$'", res: true},
+		{cond: "tag('env') MATCHES '^prod-us'", res: false},
+		{cond: "tag('absent') MATCHES '.+'", res: false},
+		{cond: "NOT tag('name') MATCHES '^api$'", res: false},
+		{cond: "tag('name') MATCHES 'API' OR tag('name') MATCHES '(?i)API'", res: true},
+	} {
+		eval, err := BuildExprF(mustParse(t, tc.cond), LogsCondDialect)
+		assert.Nil(t, err, tc.cond)
+		assert.Equal(t, tc.res, eval(log), tc.cond)
+	}
+
+	r := &solaris.Record{ID: ulidutils.NewID(), Payload: []byte(`{"user": {"email": "john@example.com"}, "msg": "request 42 failed"}`)}
+	for _, tc := range []struct {
+		cond string
+		res  bool
+	}{
+		{cond: "payload MATCHES 'request [0-9]+ failed'", res: true},
+		{cond: "payload MATCHES '^request'", res: false},
+		{cond: `payload MATCHES 'request \\d+ failed'`, res: true},
+		{cond: "json('$.user.email') MATCHES '@example[.]com$'", res: true},
+		{cond: "json('$.user.email') MATCHES '@example[.]org$'", res: false},
+		{cond: "json('$.user.name') MATCHES '.*'", res: false},
+	} {
+		eval, err := BuildExprF(mustParse(t, tc.cond), NewRecordsCondDialect())
+		assert.Nil(t, err, tc.cond)
+		assert.Equal(t, tc.res, eval(r), tc.cond)
+	}
+
+	_, err := BuildExprF(mustParse(t, "logID MATCHES 'a(b'"), LogsCondDialect)
+	assert.ErrorIs(t, err, errors.ErrInvalid)
+	assert.Contains(t, err.Error(), "a(b")
+	for _, cond := range []string{"records MATCHES '1'", "logID MATCHES 1", "logID MATCHES logID", "tag('a') MATCHES tag('b')"} {
+		_, err = BuildExprF(mustParse(t, cond), LogsCondDialect)
+		assert.ErrorIs(t, err, errors.ErrInvalid, cond)
+	}
+	for _, cond := range []string{"ctime MATCHES '1'", "payload MATCHES '[a'"} {
+		_, err = BuildExprF(mustParse(t, cond), RecordsCondDialect)
+		assert.ErrorIs(t, err, errors.ErrInvalid, cond)
+	}
+}
+
+func TestRecordCondEval_Time(t *testing.T) {
+	now := time.Now()
+	r := &solaris.Record{ID: ulidutils.NewID(), CreatedAt: timestamppb.New(now.Add(-10 * time.Minute))}
+	for _, tc := range []struct {
+		cond string
+		res  bool
+	}{
+		{cond: "ctime < now()", res: true},
+		{cond: "ctime > now() - '15m'", res: true},
+		{cond: "ctime > now() - '5m'", res: false},
+		{cond: "ctime > now() - '1h' + '55m'", res: false},
+		{cond: "ctime > now()-'1d' AND ctime < now() - '1.5m'", res: true},
+		{cond: "ctime > '-15m' AND ctime < '-5m'", res: true},
+		{cond: "ctime > date('2024-03-01', 'UTC')", res: true},
+		{cond: "ctime < date('2024-03-01 10:00:00', 'Europe/Paris') + '100d'", res: false},
+		{cond: "ctime > date('-15m')", res: true},
+	} {
+		eval, err := BuildExprF(mustParse(t, tc.cond), NewRecordsCondDialect())
+		assert.Nil(t, err, tc.cond)
+		assert.Equal(t, tc.res, eval(r), tc.cond)
+	}
+
+	// the time zone of the date() changes the time point
+	r.CreatedAt = timestamppb.New(time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC))
+	eval, err := BuildExprF(mustParse(t, "ctime > date('2024-03-01 10:00:00', '+01:00')"), RecordsCondDialect)
+	assert.Nil(t, err)
+	assert.True(t, eval(r))
+	eval, err = BuildExprF(mustParse(t, "ctime > date('2024-03-01 10:00:00')"), RecordsCondDialect)
+	assert.Nil(t, err)
+	assert.False(t, eval(r))
+
+	for _, cond := range []string{"ctime > now(1)", "ctime > date()", "ctime > date('2024-03-01', 'UTC', 'a')", "ctime > date(1)",
+		"ctime > date('abc')", "ctime > date('2024-03-01', 'Mars/Olympus')", "ctime > now() - '1x'", "ctime > now() - '-1h'",
+		"payload = 'abc' + '1h'", "payload LIKE 'abc' + '1h'", "ctime = now()", "payload > now()", "now() > ctime"} {
+		_, err = BuildExprF(mustParse(t, cond), NewRecordsCondDialect())
+		assert.ErrorIs(t, err, errors.ErrInvalid, cond)
+	}
+}
+
+func TestRecordCondEval_PayloadNoAllocs(t *testing.T) {
+	r := &solaris.Record{ID: ulidutils.NewID(), Payload: []byte(strings.Repeat("some text ", 1000) + "Timeout")}
+	for _, cond := range []string{"payload LIKE '%text%timeout%'", "contains(payload, 'timeout')"} {
+		eval, err := BuildExprF(mustParse(t, cond), NewRecordsCondDialect())
+		assert.Nil(t, err)
+		// the only allocation is the payload string header returned as any, the payload is not copied
+		assert.Equal(t, 1.0, testing.AllocsPerRun(10, func() { eval(r) }), cond)
+	}
+}
+
+func mustParse(t *testing.T, cond string) *Expression {
+	expr, err := Parse(cond)
+	assert.Nil(t, err, cond)
+	return expr
+}
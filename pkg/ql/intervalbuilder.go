@@ -45,136 +45,161 @@ func NewParamIntervalBuilder[T, K any](basis intervals.Basis[T], dialect Dialect
 	return ParamIntervalBuilder[T, K]{basis: basis, dialect: dialect, param: param, ops: opsMap}
 }
 
-// Build returns a list of intervals built from the AST expression.
+// Build returns a list of intervals built from the AST expression. The intervals cover all the
+// param values, which may match the expression. The conditions for other params are considered as
+// not constraining the param, so the result may be wider than the set of values matching the
+// expression, but never narrower. The empty result means that no value may match the expression.
 func (ib *ParamIntervalBuilder[T, K]) Build(expr *Expression) ([]intervals.Interval[T], error) {
+	res, _, err := ib.build(expr)
+	return res, err
+}
+
+// build returns the intervals for the expression and whether the intervals are exact, which means that the
+// intervals were built by the param conditions only
+func (ib *ParamIntervalBuilder[T, K]) build(expr *Expression) ([]intervals.Interval[T], bool, error) {
+	if len(expr.Or) == 0 {
+		return ib.all(), false, nil
+	}
 	var res []intervals.Interval[T]
+	exact := true
 	for _, or := range expr.Or {
-		tt, err := ib.buildOR(or)
+		tt, ex, err := ib.buildOR(or)
 		if err != nil {
-			return nil, err
-		}
-		if len(tt) > 0 {
-			res = append(res, tt...)
+			return nil, false, err
 		}
+		res = append(res, tt...)
+		exact = exact && ex
 	}
 	res = ib.union(res)
-	return res, nil
+	return res, exact, nil
 }
 
-func (ib *ParamIntervalBuilder[T, K]) buildOR(or *OrCondition) ([]intervals.Interval[T], error) {
+func (ib *ParamIntervalBuilder[T, K]) buildOR(or *OrCondition) ([]intervals.Interval[T], bool, error) {
 	var groups [][]intervals.Interval[T]
+	exact := true
 	for _, and := range or.And {
-		group, err := ib.buildXCond(and)
+		group, ex, err := ib.buildXCond(and)
 		if err != nil {
-			return nil, err
-		}
-		if len(group) > 0 {
-			groups = append(groups, group)
+			return nil, false, err
 		}
+		groups = append(groups, group)
+		exact = exact && ex
 	}
-	return ib.intersect(groups), nil
+	return ib.intersect(groups), exact, nil
 }
 
-func (ib *ParamIntervalBuilder[T, K]) buildXCond(and *XCondition) ([]intervals.Interval[T], error) {
+func (ib *ParamIntervalBuilder[T, K]) buildXCond(and *XCondition) ([]intervals.Interval[T], bool, error) {
 	var res []intervals.Interval[T]
+	var exact bool
+	var err error
 	if and.Expr != nil {
-		var err error
-		res, err = ib.Build(and.Expr)
-		if err != nil {
-			return nil, err
-		}
+		res, exact, err = ib.build(and.Expr)
 	} else {
-		tt, err := ib.buildCond(and.Cond)
-		if err != nil {
-			return nil, err
-		}
-		if len(tt) > 0 {
-			res = append(res, tt...)
-		}
+		res, exact, err = ib.buildCond(and.Cond)
+	}
+	if err != nil {
+		return nil, false, err
 	}
 	if !and.Not {
-		return res, nil
+		return res, exact, nil
+	}
+	if !exact {
+		// the negation of a condition for other params may match any value of the param
+		return ib.all(), false, nil
 	}
-	var negated []intervals.Interval[T]
+	if len(res) == 0 {
+		return ib.all(), true, nil
+	}
+	// !(i1 | i2 | ...) = !i1 & !i2 & ...
+	var groups [][]intervals.Interval[T]
 	for _, t := range res {
-		negated = append(negated, ib.basis.Negate(t)...)
+		groups = append(groups, ib.basis.Negate(t))
 	}
-	return negated, nil
+	return ib.union(ib.intersect(groups)), true, nil
 }
 
-func (ib *ParamIntervalBuilder[T, K]) buildCond(cond *Condition) ([]intervals.Interval[T], error) {
+func (ib *ParamIntervalBuilder[T, K]) buildCond(cond *Condition) ([]intervals.Interval[T], bool, error) {
 	// param1
 	p1 := cond.FirstParam
-	if p1.Name(false) != ib.param { // skip not the param we look for
-		return nil, nil
+	if p1.Name(false) != ib.param { // not the param we look for, so it doesn't constrain the param
+		return ib.all(), false, nil
 	}
 	dp1, ok := ib.dialect[p1.ID()]
 	if !ok {
-		return nil, fmt.Errorf("the parameter %s must be known: %w", p1.Name(false), errors.ErrInvalid)
+		return nil, false, fmt.Errorf("the parameter %s must be known: %w", p1.Name(false), errors.ErrInvalid)
 	}
 	if dp1.Flags&PfLValue == 0 {
-		return nil, fmt.Errorf("the parameter %s must be on the left side of the condition: %w", p1.Name(false), errors.ErrInvalid)
+		return nil, false, fmt.Errorf("the parameter %s must be on the left side of the condition: %w", p1.Name(false), errors.ErrInvalid)
 	}
 	if dp1.Flags&PfNop != 0 {
-		return nil, fmt.Errorf("the parameter %s must allow operation (%s): %w", p1.Name(false), cond.Op, errors.ErrInvalid)
+		return nil, false, fmt.Errorf("the parameter %s must allow operation (%s): %w", p1.Name(false), cond.Op, errors.ErrInvalid)
 	}
 
 	// param2
 	p2 := cond.SecondParam
 	if p2 == nil {
-		return nil, fmt.Errorf("the second parameter must be specified for the parameter %s and the operation %q: %w", p1.Name(false), cond.Op, errors.ErrInvalid)
+		return nil, false, fmt.Errorf("the second parameter must be specified for the parameter %s and the operation %q: %w", p1.Name(false), cond.Op, errors.ErrInvalid)
 	}
-	if p2.Const == nil { // skip not a constant param
-		return nil, nil
+	if p2.Const == nil && p2.Function == nil { // not a constant param, so any value is possible
+		return ib.all(), false, nil
 	}
 	dp2, ok := ib.dialect[p2.ID()]
 	if !ok {
-		return nil, fmt.Errorf("the second parameter %s must be known: %w", p2.Name(false), errors.ErrInvalid)
+		return nil, false, fmt.Errorf("the second parameter %s must be known: %w", p2.Name(false), errors.ErrInvalid)
 	}
 	if dp2.Flags&PfRValue == 0 {
-		return nil, fmt.Errorf("the second parameter %s must be on the right side of the condition: %w", p2.Name(false), errors.ErrInvalid)
+		return nil, false, fmt.Errorf("the second parameter %s must be on the right side of the condition: %w", p2.Name(false), errors.ErrInvalid)
 	}
 	if dp2.Flags&PfNop != 0 {
-		return nil, fmt.Errorf("the second parameter %s must allow operation (%s): %w", p2.Name(false), cond.Op, errors.ErrInvalid)
+		return nil, false, fmt.Errorf("the second parameter %s must allow operation (%s): %w", p2.Name(false), cond.Op, errors.ErrInvalid)
+	}
+	if dp2.Flags&PfConstValue == 0 { // the function value is not a constant, so any value is possible
+		return ib.all(), false, nil
+	}
+	if err := dp2.check(p2); err != nil {
+		return nil, false, err
 	}
 
 	// operation
-	if !ib.ops[cond.Op] { // skip not the ops we look for
-		return nil, nil
+	if !ib.ops[cond.Op] { // not the ops we look for, so any value is possible
+		return ib.all(), false, nil
 	}
 	switch cond.Op {
 	case "<", ">":
 		if dp1.Flags&PfComparable == 0 && dp1.Flags&PfGreaterLess == 0 {
-			return nil, fmt.Errorf("the first parameter %s must be comparable for the operation %s: %w", p1.Name(false), cond.Op, errors.ErrInvalid)
+			return nil, false, fmt.Errorf("the first parameter %s must be comparable for the operation %s: %w", p1.Name(false), cond.Op, errors.ErrInvalid)
 		}
 		if dp2.Flags&PfComparable == 0 && dp2.Flags&PfGreaterLess == 0 {
-			return nil, fmt.Errorf("the second parameter %s must be comparable for the operation %s: %w", p2.Name(false), cond.Op, errors.ErrInvalid)
+			return nil, false, fmt.Errorf("the second parameter %s must be comparable for the operation %s: %w", p2.Name(false), cond.Op, errors.ErrInvalid)
 		}
 	case "<=", ">=", "=", "!=":
 		if dp1.Flags&PfComparable == 0 {
-			return nil, fmt.Errorf("the first parameter %s must be comparable for the operation %s: %w", p1.Name(false), cond.Op, errors.ErrInvalid)
+			return nil, false, fmt.Errorf("the first parameter %s must be comparable for the operation %s: %w", p1.Name(false), cond.Op, errors.ErrInvalid)
 		}
 		if dp2.Flags&PfComparable == 0 {
-			return nil, fmt.Errorf("the second parameter %s must be comparable for the operation %s: %w", p2.Name(false), cond.Op, errors.ErrInvalid)
+			return nil, false, fmt.Errorf("the second parameter %s must be comparable for the operation %s: %w", p2.Name(false), cond.Op, errors.ErrInvalid)
 		}
 	}
 
 	// value
 	vf, err := castValueF(dp2.ValueF, dp2.Type, dp1.Type)
 	if err != nil {
-		return nil, err
+		return nil, false, err
+	}
+	if vf, err = addDurationsF(vf, cond.Durations, dp1.Type); err != nil {
+		return nil, false, err
 	}
 	kVal, err := vf(cond.SecondParam, *new(K))
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 	tVal, ok := kVal.(T)
 	if !ok {
-		return nil, fmt.Errorf("cannot cast the second parameter value(type=%T) to interval point(type=%T): %w", kVal, tVal, errors.ErrInvalid)
+		return nil, false, fmt.Errorf("cannot cast the second parameter value(type=%T) to interval point(type=%T): %w", kVal, tVal, errors.ErrInvalid)
 	}
 
 	// intervals
-	return ib.getIntervals(cond.Op, tVal), nil
+	return ib.getIntervals(cond.Op, tVal), true, nil
 }
 
 func (ib *ParamIntervalBuilder[T, K]) union(intervalsL []intervals.Interval[T]) []intervals.Interval[T] {
@@ -219,6 +244,11 @@ func (ib *ParamIntervalBuilder[T, K]) intersect(groups [][]intervals.Interval[T]
 	return prev
 }
 
+// all returns the interval, which covers all the param values
+func (ib *ParamIntervalBuilder[T, K]) all() []intervals.Interval[T] {
+	return []intervals.Interval[T]{ib.basis.Closed(ib.basis.Min, ib.basis.Max)}
+}
+
 func (ib *ParamIntervalBuilder[T, K]) getIntervals(op string, val T) []intervals.Interval[T] {
 	switch op {
 	case "<":
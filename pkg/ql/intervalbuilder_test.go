@@ -15,9 +15,11 @@
 package ql
 
 import (
+	"github.com/solarisdb/solaris/golibs/errors"
 	"github.com/solarisdb/solaris/pkg/intervals"
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 	"unicode/utf8"
 )
 
@@ -74,3 +76,79 @@ func TestIntervalBuilder_TwoIntervals(t *testing.T) {
 	assert.Equal(t, "k", i2.L)
 	assert.Equal(t, string(utf8.MaxRune), i2.R)
 }
+
+func TestIntervalBuilder_OtherParams(t *testing.T) {
+	expr, err := Parse("t > 'k' OR x = 'a'")
+	assert.Nil(t, err)
+	ii, err := testIntervalBuilder.Build(expr)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(ii))
+	assert.True(t, ii[0].IsClosed())
+	assert.Equal(t, "", ii[0].L)
+	assert.Equal(t, string(utf8.MaxRune), ii[0].R)
+
+	expr, err = Parse("t > 'k' AND x = 'a'")
+	assert.Nil(t, err)
+	ii, err = testIntervalBuilder.Build(expr)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(ii))
+	assert.True(t, ii[0].IsOpenL())
+	assert.Equal(t, "k", ii[0].L)
+
+	expr, err = Parse("t > 'k' AND NOT (t > 'm' AND x = 'a')")
+	assert.Nil(t, err)
+	ii, err = testIntervalBuilder.Build(expr)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(ii))
+	assert.Equal(t, "k", ii[0].L)
+	assert.Equal(t, string(utf8.MaxRune), ii[0].R)
+}
+
+func TestIntervalBuilder_Not(t *testing.T) {
+	expr, err := Parse("NOT (t < 'b' OR t > 'e')")
+	assert.Nil(t, err)
+	ii, err := testIntervalBuilder.Build(expr)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(ii))
+	assert.True(t, ii[0].IsClosed())
+	assert.Equal(t, "b", ii[0].L)
+	assert.Equal(t, "e", ii[0].R)
+
+	expr, err = Parse("NOT (t < 'b' AND t > 'e')")
+	assert.Nil(t, err)
+	ii, err = testIntervalBuilder.Build(expr)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(ii))
+	assert.Equal(t, "", ii[0].L)
+	assert.Equal(t, string(utf8.MaxRune), ii[0].R)
+}
+
+func TestIntervalBuilder_TimeFunctions(t *testing.T) {
+	d := NewRecordsCondDialect()
+	now, _ := d["now"].ValueF(nil, nil)
+	ib := NewParamIntervalBuilder(intervals.BasisTime, d, "ctime", OpsGtLt)
+
+	expr, err := Parse("ctime > now() - '15m' AND ctime < date('2030-01-01 10:00:00', '+02:00') + '1h'")
+	assert.Nil(t, err)
+	ii, err := ib.Build(expr)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(ii))
+	assert.True(t, ii[0].IsOpen())
+	assert.Equal(t, now.(time.Time).Add(-15*time.Minute), ii[0].L)
+	assert.Equal(t, time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC), ii[0].R.UTC())
+
+	// the condition for another param in OR does not constrain the ctime
+	expr, err = Parse("ctime > now() - '15m' OR payload = 'a' + '1h'")
+	assert.Nil(t, err)
+	ii, err = ib.Build(expr)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(ii))
+	assert.Equal(t, intervals.BasisTime.Min, ii[0].L)
+
+	for _, cond := range []string{"ctime > date('abc')", "ctime > date(1)", "ctime > now() - 'abc'"} {
+		expr, err = Parse(cond)
+		assert.Nil(t, err)
+		_, err = ib.Build(expr)
+		assert.ErrorIs(t, err, errors.ErrInvalid, cond)
+	}
+}
@@ -0,0 +1,198 @@
+// Copyright 2024 The Solaris Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ql
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/solarisdb/solaris/api/gen/solaris/v1"
+	"github.com/solarisdb/solaris/golibs/errors"
+	"strconv"
+	"strings"
+)
+
+type (
+	// jsonPath is the compiled path of the json() function. The path elements are either
+	// the object keys (string) or the array indexes (int).
+	jsonPath []any
+
+	// jsonCache keeps the parsed payload of the last record and the compiled paths of the json() functions,
+	// so the record payload is parsed once for all the json() functions of the expression. The cache is not
+	// thread-safe.
+	jsonCache struct {
+		id      string
+		payload []byte
+		doc     any
+		err     error
+		paths   map[*Param]jsonPath
+	}
+)
+
+// parseJSONPath parses the path in the form of $.a.b[0]['c.d']. The leading $ is optional.
+func parseJSONPath(path string) (jsonPath, error) {
+	s := strings.TrimPrefix(strings.TrimSpace(path), "$")
+	if len(s) > 0 && s[0] != '.' && s[0] != '[' {
+		s = "." + s
+	}
+	var res jsonPath
+	for len(s) > 0 {
+		switch s[0] {
+		case '.':
+			s = s[1:]
+			i := strings.IndexAny(s, ".[")
+			if i < 0 {
+				i = len(s)
+			}
+			if i == 0 {
+				return nil, fmt.Errorf("empty key in the json path %q: %w", path, errors.ErrInvalid)
+			}
+			res = append(res, s[:i])
+			s = s[i:]
+		case '[':
+			s = s[1:]
+			if len(s) > 0 && (s[0] == '\'' || s[0] == '"') {
+				i := strings.IndexByte(s[1:], s[0]) + 1
+				if i == 0 || !strings.HasPrefix(s[i+1:], "]") {
+					return nil, fmt.Errorf("unterminated key in the json path %q: %w", path, errors.ErrInvalid)
+				}
+				res = append(res, s[1:i])
+				s = s[i+2:]
+				continue
+			}
+			i := strings.IndexByte(s, ']')
+			if i < 0 {
+				return nil, fmt.Errorf("unterminated index in the json path %q: %w", path, errors.ErrInvalid)
+			}
+			idx, err := strconv.Atoi(strings.TrimSpace(s[:i]))
+			if err != nil || idx < 0 {
+				return nil, fmt.Errorf("wrong index %q in the json path %q: %w", s[:i], path, errors.ErrInvalid)
+			}
+			res = append(res, idx)
+			s = s[i+1:]
+		default:
+			return nil, fmt.Errorf("unexpected %q in the json path %q: %w", s, path, errors.ErrInvalid)
+		}
+	}
+	return res, nil
+}
+
+// get returns the value of the doc element addressed by the path. The function returns false if
+// the element is not found or it is null.
+func (jp jsonPath) get(doc any) (any, bool) {
+	for _, e := range jp {
+		switch k := e.(type) {
+		case string:
+			m, ok := doc.(map[string]any)
+			if !ok {
+				return nil, false
+			}
+			if doc, ok = m[k]; !ok {
+				return nil, false
+			}
+		case int:
+			a, ok := doc.([]any)
+			if !ok || k >= len(a) {
+				return nil, false
+			}
+			doc = a[k]
+		}
+	}
+	return doc, doc != nil
+}
+
+// jsonValueF returns the ValueF of the json() function. The jc may be nil, so the record payload
+// is parsed every time the function is called.
+func jsonValueF(jc *jsonCache) valueF[*solaris.Record] {
+	return func(p *Param, r *solaris.Record) (any, error) {
+		jp, err := jc.path(p)
+		if err != nil {
+			return nil, err
+		}
+		doc, err := jc.parse(r)
+		if err != nil {
+			return nil, err
+		}
+		v, ok := jp.get(doc)
+		if !ok {
+			return nil, fmt.Errorf("no value found by the json path %q: %w", p.Function.Params[0].Name(true), errors.ErrNotExist)
+		}
+		return jsonString(v), nil
+	}
+}
+
+// path returns the compiled json path of the json() function p
+func (jc *jsonCache) path(p *Param) (jsonPath, error) {
+	if jc == nil {
+		return parseJSONPath(p.Function.Params[0].Name(true))
+	}
+	jp, ok := jc.paths[p]
+	if !ok {
+		var err error
+		if jp, err = parseJSONPath(p.Function.Params[0].Name(true)); err != nil {
+			return nil, err
+		}
+		if jc.paths == nil {
+			jc.paths = make(map[*Param]jsonPath)
+		}
+		jc.paths[p] = jp
+	}
+	return jp, nil
+}
+
+// parse returns the parsed payload of the record r. The record objects may be re-used for different
+// records, so the cached payload is returned only if both the record ID and the payload are the same.
+func (jc *jsonCache) parse(r *solaris.Record) (any, error) {
+	if jc == nil {
+		return parseJSON(r.Payload)
+	}
+	if len(r.ID) == 0 || jc.id != r.ID || !sameBytes(jc.payload, r.Payload) {
+		jc.doc, jc.err = parseJSON(r.Payload)
+		jc.id = r.ID
+		jc.payload = r.Payload
+	}
+	return jc.doc, jc.err
+}
+
+func parseJSON(buf []byte) (any, error) {
+	d := json.NewDecoder(bytes.NewReader(buf))
+	// the numbers are kept as is, so they are compared as they are written
+	d.UseNumber()
+	var doc any
+	if err := d.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("could not parse the payload as json: %w", errors.ErrInvalid)
+	}
+	return doc, nil
+}
+
+// jsonString returns the string representation of the json value v. The strings are returned as is,
+// the objects and the arrays are returned in the json form.
+func jsonString(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	buf, _ := json.Marshal(v)
+	return string(buf)
+}
+
+// sameBytes returns whether the slices a and b refer to the same memory
+func sameBytes(a, b []byte) bool {
+	return len(a) == len(b) && (len(a) == 0 || &a[0] == &b[0])
+}
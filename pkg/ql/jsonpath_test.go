@@ -0,0 +1,72 @@
+// Copyright 2024 The Solaris Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ql
+
+import (
+	"github.com/solarisdb/solaris/api/gen/solaris/v1"
+	"github.com/solarisdb/solaris/golibs/errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseJSONPath(t *testing.T) {
+	for path, jp := range map[string]jsonPath{
+		"$":                   nil,
+		"":                    nil,
+		"$.a":                 {"a"},
+		"a.b":                 {"a", "b"},
+		"$.a[0].b":            {"a", 0, "b"},
+		"$['a.b'][ 12 ]":      {"a.b", 12},
+		`$["a]"].c`:           {"a]", "c"},
+		"[1][2]":              {1, 2},
+		"$.user.id['x y z']":  {"user", "id", "x y z"},
+		"$.a.b.c.d.e.f.g.h.i": {"a", "b", "c", "d", "e", "f", "g", "h", "i"},
+	} {
+		res, err := parseJSONPath(path)
+		assert.Nil(t, err, path)
+		assert.Equal(t, jp, res, path)
+	}
+	for _, path := range []string{"$.", "$..a", "$[", "$[a]", "$[-1]", "$['a'", "$['a'x]", "$.a["} {
+		_, err := parseJSONPath(path)
+		assert.ErrorIs(t, err, errors.ErrInvalid, path)
+	}
+}
+
+func TestJSONCache(t *testing.T) {
+	jc := new(jsonCache)
+	r := &solaris.Record{ID: "1", Payload: []byte(`{"a": 1}`)}
+	doc, err := jc.parse(r)
+	assert.Nil(t, err)
+	assert.Equal(t, jc.doc, doc)
+
+	// the same record is not parsed again
+	jc.doc = "cached"
+	doc, _ = jc.parse(r)
+	assert.Equal(t, "cached", doc)
+
+	// the record object is re-used for another record
+	r.ID = "2"
+	r.Payload = []byte(`{"a": 2}`)
+	doc, err = jc.parse(r)
+	assert.Nil(t, err)
+	v, ok := jsonPath{"a"}.get(doc)
+	assert.True(t, ok)
+	assert.Equal(t, "2", jsonString(v))
+
+	r.ID = "3"
+	r.Payload = []byte(`{"a": `)
+	_, err = jc.parse(r)
+	assert.ErrorIs(t, err, errors.ErrInvalid)
+}
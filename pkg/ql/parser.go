@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"github.com/alecthomas/participle/v2"
 	"github.com/alecthomas/participle/v2/lexer"
+	"strconv"
 	"strings"
 )
 
@@ -40,11 +41,19 @@ type (
 	}
 
 	// Condition is a unary or binary logical operation which has first mandatory param and
-	// optional operation and second param
+	// optional operation and second param. The durations may be added to or subtracted from
+	// the second param, e.g. ctime > now() - '15m'
 	Condition struct {
-		FirstParam  Param  `  @@`
-		Op          string ` {@("<"|">"|">="|"<="|"!="|"="|"IN"|"LIKE")`
-		SecondParam *Param ` @@}`
+		FirstParam  Param       `  @@`
+		Op          string      ` {@("<"|">"|">="|"<="|"!="|"="|"IN"|"LIKE"|"MATCHES")`
+		SecondParam *Param      ` @@`
+		Durations   []*Duration ` { @@ } }`
+	}
+
+	// Duration is the duration string (e.g. '15m') added to or subtracted from a param
+	Duration struct {
+		Op    string ` @("+"|"-")`
+		Value string ` @String`
 	}
 
 	// Param describes a parameter either a constant (string or number), function, identifier or an array of constants
@@ -55,9 +64,9 @@ type (
 		Array      []*Const  `|"[" (@@ {"," @@})?"]"`
 	}
 
-	// Const contains the constant either string or float32 value
+	// Const contains the constant either string or float64 value
 	Const struct {
-		Number *float32 ` @Number`
+		Number *float64 ` @Number`
 		String *string  ` | @String`
 	}
 
@@ -70,11 +79,11 @@ type (
 
 var (
 	sqlLexer = lexer.MustSimple([]lexer.SimpleRule{
-		{`Keyword`, `(?i)\b(AND|OR|NOT|IN|LIKE)\b`},
+		{`Keyword`, `(?i)\b(AND|OR|NOT|IN|LIKE|MATCHES)\b`},
 		{`Ident`, `[a-zA-Z_][a-zA-Z0-9_]*`},
 		{`Number`, `[-+]?\d*\.?\d+([eE][-+]?\d+)?`},
 		{`String`, `'[^']*'|"[^"]*"`},
-		{`Operators`, `!=|<=|>=|[,()=<>\]\[]`},
+		{`Operators`, `!=|<=|>=|[-+,()=<>\]\[]`},
 		{"whitespace", `\s+`},
 	})
 
@@ -146,7 +155,36 @@ func (c Const) Value() string {
 	if c.String != nil {
 		return *c.String
 	}
-	return fmt.Sprintf("%f", *c.Number)
+	return strconv.FormatFloat(*c.Number, 'f', -1, 64)
+}
+
+// HasParam returns whether the expression refers to the parameter with the id provided (see Param.ID())
+func (e *Expression) HasParam(id string) bool {
+	for _, or := range e.Or {
+		for _, xc := range or.And {
+			if xc.Expr != nil && xc.Expr.HasParam(id) {
+				return true
+			}
+			if xc.Cond != nil && (xc.Cond.FirstParam.hasParam(id) || (xc.Cond.SecondParam != nil && xc.Cond.SecondParam.hasParam(id))) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (p Param) hasParam(id string) bool {
+	if p.ID() == id {
+		return true
+	}
+	if p.Function != nil {
+		for _, fp := range p.Function.Params {
+			if fp.hasParam(id) {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 // Parse parses the expr and in case of success returns AST
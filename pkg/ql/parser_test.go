@@ -25,7 +25,7 @@ func TestParseParam(t *testing.T) {
 	assert.Nil(t, err)
 
 	cond := expr.Or[0].And[0].Cond
-	assert.Equal(t, float32(1234.0), *cond.FirstParam.Const.Number)
+	assert.Equal(t, float64(1234.0), *cond.FirstParam.Const.Number)
 	assert.Equal(t, NumberParamID, cond.FirstParam.ID())
 
 	expr, err = Parse("'1234'")
@@ -53,7 +53,7 @@ func TestParseParam(t *testing.T) {
 	assert.Nil(t, err)
 
 	cond = expr.Or[0].And[0].Cond
-	assert.Equal(t, Function{Name: "lala", Params: []*Param{{Const: &Const{Number: cast.Ptr(float32(1234))}}}}, *cond.FirstParam.Function)
+	assert.Equal(t, Function{Name: "lala", Params: []*Param{{Const: &Const{Number: cast.Ptr(float64(1234))}}}}, *cond.FirstParam.Function)
 	assert.Equal(t, "lala", cond.FirstParam.ID())
 
 	_, err = Parse("lala ( 1234,hhh)")
@@ -67,7 +67,7 @@ func TestParseCondition(t *testing.T) {
 	assert.Nil(t, err)
 
 	cond := expr.Or[0].And[0].Cond
-	assert.Equal(t, float32(1234.0), *cond.FirstParam.Const.Number)
+	assert.Equal(t, float64(1234.0), *cond.FirstParam.Const.Number)
 	assert.Nil(t, expr.Or[0].And[0].Cond.SecondParam)
 
 	expr, err = Parse("f1() != f2('asdf')")
@@ -77,6 +77,21 @@ func TestParseCondition(t *testing.T) {
 	assert.Equal(t, "f1", cond.FirstParam.Function.Name)
 	assert.Equal(t, "!=", cond.Op)
 	assert.Equal(t, "f2", cond.SecondParam.Function.Name)
+
+	expr, err = Parse("logID matches '^a.*$'")
+	assert.Nil(t, err)
+
+	cond = expr.Or[0].And[0].Cond
+	assert.Equal(t, "logID", cond.FirstParam.Identifier)
+	assert.Equal(t, "matches", cond.Op)
+	assert.Equal(t, "^a.*$", cond.SecondParam.Name(true))
+
+	expr, err = Parse("ctime > now() - '15m' + '1h'")
+	assert.Nil(t, err)
+
+	cond = expr.Or[0].And[0].Cond
+	assert.Equal(t, "now", cond.SecondParam.Function.Name)
+	assert.Equal(t, []*Duration{{Op: "-", Value: "15m"}, {Op: "+", Value: "1h"}}, cond.Durations)
 }
 
 func TestExpressions(t *testing.T) {
@@ -89,6 +104,8 @@ func TestExpressions(t *testing.T) {
 	testOk(t, "1234 != 1234 and f()")
 	testOk(t, "1234 != 1234 and (f(1234, var2, f2(34, f1())) or var1 = 'sdf')")
 	testOk(t, "f1('abc') in [1,2,3]")
+	testOk(t, "f1('abc') MATCHES '[a-z]+' and var1 like 'a%'")
+	testOk(t, "var1 > date('2024-03-01', 'UTC')-'1d' and var1 < -1")
 }
 
 func testOk(t *testing.T, e string) {
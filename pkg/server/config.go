@@ -20,6 +20,7 @@ import (
 	"github.com/solarisdb/solaris/golibs/config"
 	"github.com/solarisdb/solaris/golibs/logging"
 	"github.com/solarisdb/solaris/golibs/transport"
+	"time"
 )
 
 type (
@@ -35,16 +36,31 @@ type (
 		// MaxOpenedLogFiles allows to control number of files opened at a time to work with the solaris data
 		// Increasing the number allows to increase the system performance for accessing to random group of logs
 		MaxOpenedLogFiles int
+		// DeletedLogsGracePeriod defines how long the logs marked deleted are kept before being purged permanently
+		DeletedLogsGracePeriod time.Duration
+		// GCInterval defines how often the deleted logs are purged. The value 0 disables the purging.
+		GCInterval time.Duration
+		// RetentionInterval defines how often the logs retention policies are applied. The value 0 disables
+		// the retention.
+		RetentionInterval time.Duration
+		// RetentionMaxAge, RetentionMaxRecords and RetentionMaxSize define the default retention policy for
+		// the logs, which have no their own one. The value 0 means there is no the limit.
+		RetentionMaxAge     time.Duration
+		RetentionMaxRecords int64
+		RetentionMaxSize    int64
 	}
 )
 
 // getDefaultConfig returns the default server config
 func getDefaultConfig() *Config {
 	return &Config{
-		GrpcTransport:     transport.GetDefaultGRPCConfig(),
-		MetaDBFilePath:    ":memory:",
-		LocalDBFilePath:   "slogs",
-		MaxOpenedLogFiles: 100,
+		GrpcTransport:          transport.GetDefaultGRPCConfig(),
+		MetaDBFilePath:         ":memory:",
+		LocalDBFilePath:        "slogs",
+		MaxOpenedLogFiles:      100,
+		DeletedLogsGracePeriod: 24 * time.Hour,
+		GCInterval:             time.Minute,
+		RetentionInterval:      time.Minute,
 	}
 }
 
@@ -17,10 +17,13 @@ package server
 import (
 	"context"
 	"fmt"
+	"github.com/solarisdb/solaris/api/gen/solaris/v1"
 	"github.com/solarisdb/solaris/golibs/errors"
 	"github.com/solarisdb/solaris/golibs/files"
 	"github.com/solarisdb/solaris/golibs/logging"
+	"github.com/solarisdb/solaris/pkg/api"
 	"github.com/solarisdb/solaris/pkg/grpc"
+	"github.com/solarisdb/solaris/pkg/storage"
 	"github.com/solarisdb/solaris/pkg/storage/buntdb"
 	"github.com/solarisdb/solaris/pkg/storage/cache"
 	"github.com/solarisdb/solaris/pkg/storage/chunkfs"
@@ -28,6 +31,7 @@ import (
 	"github.com/solarisdb/solaris/pkg/version"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
+	"time"
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/logrange/linker"
@@ -46,17 +50,35 @@ func Run(ctx context.Context, cfg *Config) error {
 		return err
 	}
 
+	// public API
+	svc := api.NewService()
+
 	// gRPC server
 	var grpcRegF grpc.RegisterF = func(gs *ggrpc.Server) error {
 		grpc_health_v1.RegisterHealthServer(gs, health.NewServer())
+		solaris.RegisterServiceServer(gs, svc)
 		return nil
 	}
 
 	inj := linker.New()
+	inj.Register(linker.Component{Name: "", Value: svc})
 	inj.Register(linker.Component{Name: "", Value: grpc.NewServer(grpc.Config{Transport: *cfg.GrpcTransport, RegisterEndpoints: grpcRegF})})
 	inj.Register(linker.Component{Name: "", Value: cache.NewCachedStorage(buntdb.NewStorage(buntdb.Config{DBFilePath: cfg.MetaDBFilePath}))})
 	inj.Register(linker.Component{Name: "", Value: chunkfs.NewProvider(cfg.LocalDBFilePath, cfg.MaxOpenedLogFiles, chunkfs.GetDefaultConfig())})
 	inj.Register(linker.Component{Name: "", Value: logfs.NewLocalLog(logfs.GetDefaultConfig())})
+	inj.Register(linker.Component{Name: "", Value: storage.NewNotifier()})
+	inj.Register(linker.Component{Name: "", Value: logfs.NewCollector(logfs.CollectorConfig{
+		Interval:    cfg.GCInterval,
+		GracePeriod: cfg.DeletedLogsGracePeriod,
+	})})
+	inj.Register(linker.Component{Name: "", Value: logfs.NewRetainer(logfs.RetainerConfig{
+		Interval: cfg.RetentionInterval,
+		Default: &solaris.Retention{
+			MaxAgeSeconds: int64(cfg.RetentionMaxAge / time.Second),
+			MaxRecords:    cfg.RetentionMaxRecords,
+			MaxSize:       cfg.RetentionMaxSize,
+		},
+	})})
 
 	inj.Init(ctx)
 	<-ctx.Done()
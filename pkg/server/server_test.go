@@ -0,0 +1,368 @@
+// Copyright 2024 The Solaris Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"github.com/solarisdb/solaris/api/gen/solaris/v1"
+	"github.com/solarisdb/solaris/golibs/transport"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"io"
+	"net"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestRun_PublicAPI(t *testing.T) {
+	client, stop := runTestServer(t)
+	defer stop()
+	ctx := context.Background()
+
+	l1, err := client.CreateLog(ctx, &solaris.Log{Tags: map[string]string{"app": "test", "n": "1"}})
+	assert.Nil(t, err)
+	assert.NotEmpty(t, l1.ID)
+	l2, err := client.CreateLog(ctx, &solaris.Log{Tags: map[string]string{"app": "test", "n": "2"}})
+	assert.Nil(t, err)
+
+	// the stale version update is rejected, and the cache keeps the current version
+	ul, err := client.UpdateLog(ctx, &solaris.Log{ID: l2.ID, Tags: l2.Tags, Version: l2.Version})
+	assert.Nil(t, err)
+	assert.Equal(t, l2.Version+1, ul.Version)
+	_, err = client.UpdateLog(ctx, &solaris.Log{ID: l2.ID, Tags: l2.Tags, Version: l2.Version})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	ul, err = client.UpdateLog(ctx, &solaris.Log{ID: l2.ID, Tags: l2.Tags, Version: ul.Version})
+	assert.Nil(t, err)
+	assert.Equal(t, l2.Version+2, ul.Version)
+	pl, err := client.PatchLogTags(ctx, &solaris.PatchLogTagsRequest{LogID: l2.ID, Set: map[string]string{"patched": "1"}})
+	assert.Nil(t, err)
+	assert.Equal(t, "1", pl.Tags["patched"])
+	assert.Equal(t, "2", pl.Tags["n"])
+	_, err = client.PatchLogTags(ctx, &solaris.PatchLogTagsRequest{LogID: l2.ID, Remove: []string{"patched"}, Version: ul.Version})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	bp, err := client.BulkPatchLogTags(ctx, &solaris.BulkPatchLogTagsRequest{Condition: "tag('app') = 'test'", Remove: []string{"patched"}})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(bp.PatchedIDs))
+	ql, err := client.QueryLogs(ctx, &solaris.QueryLogsRequest{Condition: "tag('patched') = '1'"})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(ql.Logs))
+
+	var l1Results []*solaris.AppendRecordsResult
+	for i := 0; i < 3; i++ {
+		for _, lid := range []string{l1.ID, l2.ID} {
+			ar, err := client.AppendRecords(ctx, &solaris.AppendRecordsRequest{LogID: lid,
+				Records: []*solaris.Record{{Payload: []byte(fmt.Sprintf("%s-%d", lid, i))}}})
+			assert.Nil(t, err)
+			assert.Equal(t, int64(1), ar.Added)
+			assert.Equal(t, ar.FirstID, ar.LastID)
+			if lid == l1.ID {
+				l1Results = append(l1Results, ar)
+			}
+		}
+	}
+	_, err = client.AppendRecords(ctx, &solaris.AppendRecordsRequest{LogID: "unknown", Records: []*solaris.Record{{Payload: []byte("a")}}})
+	assert.NotNil(t, err)
+	_, err = client.AppendRecords(ctx, &solaris.AppendRecordsRequest{LogID: l1.ID, Records: []*solaris.Record{{Payload: []byte("a")}},
+		ExpectedLastRecordID: &l1Results[1].LastID})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	qr, err := client.QueryRecords(ctx, &solaris.QueryRecordsRequest{LogIDs: []string{l1.ID}, Limit: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(qr.Records))
+	assert.Equal(t, []byte(l1.ID+"-0"), qr.Records[0].Payload)
+	assert.NotEmpty(t, qr.NextPageID)
+	for i, r := range qr.Records {
+		assert.Equal(t, l1Results[i].FirstID, r.ID)
+		assert.Equal(t, l1Results[i].FirstCreatedAt.AsTime(), r.CreatedAt.AsTime())
+	}
+
+	qr, err = client.QueryRecords(ctx, &solaris.QueryRecordsRequest{LogIDs: []string{l1.ID}, StartRecordID: qr.NextPageID, Limit: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(qr.Records))
+	assert.Equal(t, []byte(l1.ID+"-2"), qr.Records[0].Payload)
+
+	qr, err = client.QueryRecords(ctx, &solaris.QueryRecordsRequest{LogsCondition: "tag('app') = 'test'", Descending: true, Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, 6, len(qr.Records))
+	assert.Equal(t, []byte(l2.ID+"-2"), qr.Records[0].Payload)
+	assert.Equal(t, []byte(l1.ID+"-0"), qr.Records[5].Payload)
+
+	cr, err := client.CountRecords(ctx, &solaris.QueryRecordsRequest{LogIDs: []string{l1.ID}})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), cr.Total)
+	cr, err = client.CountRecords(ctx, &solaris.QueryRecordsRequest{LogsCondition: "tag('app') = 'test'"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(6), cr.Total)
+	cr, err = client.CountRecords(ctx, &solaris.QueryRecordsRequest{LogsCondition: "tag('app') = 'test'", Condition: "ctime < '1'"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), cr.Total)
+
+	ql, err = client.QueryLogs(ctx, &solaris.QueryLogsRequest{Condition: "tag('n') = '2'"})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(ql.Logs))
+	assert.Equal(t, l2.ID, ql.Logs[0].ID)
+	ql, err = client.QueryLogs(ctx, &solaris.QueryLogsRequest{SortBy: solaris.LogsSortBy_LOGS_SORT_BY_UPDATED_AT, Descending: true})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), ql.Total)
+	assert.Equal(t, []string{l2.ID, l1.ID}, toIDs(ql.Logs))
+	tf, err := client.GetTagFacets(ctx, &solaris.GetTagFacetsRequest{Tag: "n"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), tf.Total)
+	assert.Equal(t, "1", tf.Facets[0].Value)
+	assert.Equal(t, int64(1), tf.Facets[0].Count)
+	tf, err = client.GetTagFacets(ctx, &solaris.GetTagFacetsRequest{Condition: "tag('n') = '2'", Limit: 1})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), tf.Total)
+	assert.Equal(t, 1, len(tf.Facets))
+	assert.Equal(t, "app", tf.Facets[0].Value)
+	_, err = client.GetTagFacets(ctx, &solaris.GetTagFacetsRequest{Condition: "tag('n') ="})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	dr, err := client.DeleteLogs(ctx, &solaris.DeleteLogsRequest{Condition: "tag('n') = '2'"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{l2.ID}, dr.DeletedIDs)
+	_, err = client.AppendRecords(ctx, &solaris.AppendRecordsRequest{LogID: l2.ID, Records: []*solaris.Record{{Payload: []byte("a")}}})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	ur, err := client.UndeleteLogs(ctx, &solaris.UndeleteLogsRequest{Condition: "tag('app') = 'test'"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{l2.ID}, ur.UndeletedIDs)
+	cr, err = client.CountRecords(ctx, &solaris.QueryRecordsRequest{LogIDs: []string{l2.ID}})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), cr.Total)
+
+	tr, err := client.TruncateLog(ctx, &solaris.TruncateLogRequest{LogID: l1.ID, BeforeRecordID: l1Results[1].FirstID})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), tr.Removed)
+	qr, err = client.QueryRecords(ctx, &solaris.QueryRecordsRequest{LogIDs: []string{l1.ID}, Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(qr.Records))
+	assert.Equal(t, l1Results[1].FirstID, qr.Records[0].ID)
+	_, err = client.TruncateLog(ctx, &solaris.TruncateLogRequest{LogID: "unknown", BeforeTime: timestamppb.Now()})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = client.TruncateLog(ctx, &solaris.TruncateLogRequest{LogID: l1.ID})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	ls, err := client.GetLogStats(ctx, &solaris.GetLogStatsRequest{LogID: l1.ID})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), ls.Records)
+	assert.Equal(t, l1Results[1].FirstID, ls.FirstRecordID)
+	assert.Equal(t, l1Results[2].LastID, ls.LastRecordID)
+	ql, err = client.QueryLogs(ctx, &solaris.QueryLogsRequest{Condition: "tag('app') = 'test'"})
+	assert.Nil(t, err)
+	for _, l := range ql.Logs {
+		assert.True(t, l.Records > 0)
+	}
+	_, err = client.GetLogStats(ctx, &solaris.GetLogStatsRequest{LogID: "unknown"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestRun_TailRecords(t *testing.T) {
+	client, stop := runTestServer(t)
+	defer stop()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	l1, err := client.CreateLog(ctx, &solaris.Log{Tags: map[string]string{"app": "tail"}})
+	assert.Nil(t, err)
+	l2, err := client.CreateLog(ctx, &solaris.Log{Tags: map[string]string{"app": "tail"}})
+	assert.Nil(t, err)
+	_, err = client.AppendRecords(ctx, &solaris.AppendRecordsRequest{LogID: l1.ID, Records: []*solaris.Record{{Payload: []byte("old")}}})
+	assert.Nil(t, err)
+
+	tctx, tcancel := context.WithCancel(ctx)
+	stream, err := client.TailRecords(tctx, &solaris.TailRecordsRequest{LogsCondition: "tag('app') = 'tail'"})
+	assert.Nil(t, err)
+	res, err := stream.Recv()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(res.Records))
+	assert.Equal(t, []byte("old"), res.Records[0].Payload)
+
+	for _, lid := range []string{l2.ID, l1.ID} {
+		_, err = client.AppendRecords(ctx, &solaris.AppendRecordsRequest{LogID: lid, Records: []*solaris.Record{{Payload: []byte(lid + "-1")}, {Payload: []byte(lid + "-2")}}})
+		assert.Nil(t, err)
+	}
+	recs := map[string][][]byte{}
+	for n := 0; n < 4; {
+		res, err = stream.Recv()
+		assert.Nil(t, err)
+		for _, r := range res.Records {
+			recs[r.LogID] = append(recs[r.LogID], r.Payload)
+			n++
+		}
+	}
+	assert.Equal(t, [][]byte{[]byte(l1.ID + "-1"), []byte(l1.ID + "-2")}, recs[l1.ID])
+	assert.Equal(t, [][]byte{[]byte(l2.ID + "-1"), []byte(l2.ID + "-2")}, recs[l2.ID])
+
+	tcancel()
+	_, err = stream.Recv()
+	assert.NotNil(t, err)
+
+	// the record condition, no record matches
+	rctx, rcancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer rcancel()
+	stream, err = client.TailRecords(rctx, &solaris.TailRecordsRequest{LogIDs: []string{l1.ID}, Condition: "ctime < '1'"})
+	assert.Nil(t, err)
+	_, err = client.AppendRecords(ctx, &solaris.AppendRecordsRequest{LogID: l1.ID, Records: []*solaris.Record{{Payload: []byte("a")}}})
+	assert.Nil(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+
+	// wrong condition
+	stream, err = client.TailRecords(ctx, &solaris.TailRecordsRequest{LogIDs: []string{l1.ID}, Condition: "ctime <"})
+	assert.Nil(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestRun_AppendRecordsStream(t *testing.T) {
+	client, stop := runTestServer(t)
+	defer stop()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	l1, err := client.CreateLog(ctx, &solaris.Log{})
+	assert.Nil(t, err)
+	l2, err := client.CreateLog(ctx, &solaris.Log{})
+	assert.Nil(t, err)
+
+	stream, err := client.AppendRecordsStream(ctx)
+	assert.Nil(t, err)
+	for i := 0; i < 10; i++ {
+		lid := []string{l1.ID, l2.ID}[i%2]
+		err = stream.Send(&solaris.AppendRecordsRequest{LogID: lid, Records: []*solaris.Record{{Payload: []byte("a")}, {Payload: []byte("b")}}})
+		assert.Nil(t, err)
+	}
+	assert.Nil(t, stream.CloseSend())
+	var acks []*solaris.AppendRecordsAck
+	for {
+		ack, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		assert.Nil(t, err)
+		acks = append(acks, ack)
+	}
+	assert.Equal(t, 10, len(acks))
+	for i, ack := range acks {
+		assert.Equal(t, int64(i+1), ack.SeqNo)
+		assert.Equal(t, []string{l1.ID, l2.ID}[i%2], ack.LogID)
+		assert.Equal(t, int64(2), ack.Result.Added)
+		assert.True(t, ack.Result.FirstID < ack.Result.LastID)
+	}
+
+	qr, err := client.QueryRecords(ctx, &solaris.QueryRecordsRequest{LogIDs: []string{l2.ID}, Limit: 100})
+	assert.Nil(t, err)
+	assert.Equal(t, 10, len(qr.Records))
+	assert.Equal(t, acks[1].Result.FirstID, qr.Records[0].ID)
+	assert.Equal(t, acks[9].Result.LastID, qr.Records[9].ID)
+
+	// unknown log interrupts the stream
+	stream, err = client.AppendRecordsStream(ctx)
+	assert.Nil(t, err)
+	assert.Nil(t, stream.Send(&solaris.AppendRecordsRequest{LogID: l1.ID, Records: []*solaris.Record{{Payload: []byte("a")}}}))
+	assert.Nil(t, stream.Send(&solaris.AppendRecordsRequest{LogID: "unknown", Records: []*solaris.Record{{Payload: []byte("a")}}}))
+	ack, err := stream.Recv()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), ack.SeqNo)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestRun_AppendRecordsBatch(t *testing.T) {
+	client, stop := runTestServer(t)
+	defer stop()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	l1, err := client.CreateLog(ctx, &solaris.Log{})
+	assert.Nil(t, err)
+	l2, err := client.CreateLog(ctx, &solaris.Log{})
+	assert.Nil(t, err)
+
+	br, err := client.AppendRecordsBatch(ctx, &solaris.AppendRecordsBatchRequest{Requests: []*solaris.AppendRecordsRequest{
+		{LogID: l1.ID, Records: []*solaris.Record{{Payload: []byte("a")}}},
+		{LogID: l2.ID, Records: []*solaris.Record{{Payload: []byte("b")}, {Payload: []byte("c")}}},
+	}})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(br.Results))
+	assert.Equal(t, int64(1), br.Results[0].Added)
+	assert.Equal(t, int64(2), br.Results[1].Added)
+
+	_, err = client.AppendRecordsBatch(ctx, &solaris.AppendRecordsBatchRequest{Requests: []*solaris.AppendRecordsRequest{
+		{LogID: l1.ID, Records: []*solaris.Record{{Payload: []byte("a")}}},
+		{LogID: l2.ID, Records: []*solaris.Record{{Payload: []byte("b")}}, ExpectedLastRecordID: &br.Results[1].FirstID},
+	}})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	_, err = client.AppendRecordsBatch(ctx, &solaris.AppendRecordsBatchRequest{Requests: []*solaris.AppendRecordsRequest{
+		{LogID: l1.ID, Records: []*solaris.Record{{Payload: []byte("a")}}},
+		{LogID: "unknown", Records: []*solaris.Record{{Payload: []byte("b")}}},
+	}})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	cr, err := client.CountRecords(ctx, &solaris.QueryRecordsRequest{LogIDs: []string{l1.ID, l2.ID}})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), cr.Total)
+}
+
+// runTestServer starts the server on a free port and returns the client connected to it,
+// and the function which stops the server
+func runTestServer(t *testing.T) (solaris.ServiceClient, func()) {
+	dir, err := os.MkdirTemp("", "solaris-test")
+	assert.Nil(t, err)
+
+	port := getFreePort(t)
+	cfg := getDefaultConfig()
+	cfg.GrpcTransport = &transport.Config{Network: "tcp", Address: "localhost", Port: port}
+	cfg.MetaDBFilePath = ""
+	cfg.LocalDBFilePath = dir
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- Run(ctx, cfg)
+	}()
+
+	dctx, dcancel := context.WithTimeout(ctx, 5*time.Second)
+	defer dcancel()
+	conn, err := grpc.DialContext(dctx, cfg.GrpcTransport.Addr(), grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
+	assert.Nil(t, err)
+	return solaris.NewServiceClient(conn), func() {
+		conn.Close()
+		cancel()
+		assert.Nil(t, <-done)
+		os.RemoveAll(dir)
+	}
+}
+
+func getFreePort(t *testing.T) int {
+	l, err := net.Listen("tcp", "localhost:0")
+	assert.Nil(t, err)
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port
+}
+
+func toIDs(logs []*solaris.Log) []string {
+	res := make([]string, len(logs))
+	for i, l := range logs {
+		res[i] = l.ID
+	}
+	return res
+}
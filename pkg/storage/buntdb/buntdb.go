@@ -27,8 +27,11 @@ import (
 	"github.com/solarisdb/solaris/pkg/storage/logfs"
 	"github.com/tidwall/buntdb"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"maps"
+	"net/url"
 	"slices"
 	"strings"
+	"time"
 )
 
 type (
@@ -50,11 +53,22 @@ type (
 	logEntry struct {
 		*solaris.Log
 		Deleted bool `json:"deleted"`
+		// Purging is set for the deleted log when its data is being removed, so it cannot be undeleted
+		Purging bool `json:"purging,omitempty"`
 	}
 
 	chnkEntry struct {
 		logfs.ChunkInfo
 	}
+
+	// logsCond is the compiled logs condition
+	logsCond struct {
+		expr *ql.Expression
+		tstF ql.ExprF[*solaris.Log]
+		// records is set if the condition refers to the log records number, which is not stored
+		// with the log, but calculated by the log chunks
+		records bool
+	}
 )
 
 // NewStorage creates new logs meta storage based on BuntDB
@@ -77,7 +91,10 @@ func (s *Storage) Init(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("buntdb.Open(%s) failed: %w", path, err)
 	}
-	return nil
+	if err = s.buildTagsIndex(ctx); err != nil {
+		return err
+	}
+	return s.buildSortIndex(ctx)
 }
 
 // Shutdown implements linker.Shutdowner
@@ -96,6 +113,7 @@ func (s *Storage) CreateLog(ctx context.Context, log *solaris.Log) (*solaris.Log
 	le.ID = ulidutils.NewID()
 	le.CreatedAt = timestamppb.Now()
 	le.UpdatedAt = le.CreatedAt
+	le.Version = 1
 
 	tx := mustBeginTx(s.db, true)
 	defer mustRollback(tx)
@@ -106,6 +124,12 @@ func (s *Storage) CreateLog(ctx context.Context, log *solaris.Log) (*solaris.Log
 	if _, _, err := tx.Set(key, val, nil); err != nil {
 		return nil, fmt.Errorf("tx.Set(%s, %s) failed: %w", key, val, err)
 	}
+	if err := updateTagsIndex(tx, le.ID, nil, le.Tags); err != nil {
+		return nil, err
+	}
+	if err := updateSortIndex(tx, le.ID, nil, sortIndexKeys(le.Log)); err != nil {
+		return nil, err
+	}
 
 	mustCommit(tx)
 	return toLog(le), nil
@@ -121,10 +145,14 @@ func (s *Storage) GetLogByID(ctx context.Context, id string) (*solaris.Log, erro
 	defer mustRollback(tx)
 
 	e, err := s.getLogEntry(tx, logKey(id), true)
-	return toLog(e), err
+	if err != nil {
+		return nil, err
+	}
+	return toLogWithRecords(ctx, tx, e)
 }
 
-// UpdateLog implements storage.Logs
+// UpdateLog implements storage.Logs. If the log version is provided, the log is updated only if
+// the stored log has the same version, otherwise errors.ErrConflict is returned.
 func (s *Storage) UpdateLog(ctx context.Context, log *solaris.Log) (*solaris.Log, error) {
 	if len(log.ID) == 0 {
 		return nil, fmt.Errorf("log id must be specified: %w", errors.ErrInvalid)
@@ -133,13 +161,17 @@ func (s *Storage) UpdateLog(ctx context.Context, log *solaris.Log) (*solaris.Log
 	tx := mustBeginTx(s.db, true)
 	defer mustRollback(tx)
 
-	_, err := s.getLogEntry(tx, logKey(log.ID), true)
+	cur, err := s.getLogEntry(tx, logKey(log.ID), true)
 	if err != nil {
 		return nil, err
 	}
+	if log.Version != 0 && log.Version != cur.Version {
+		return nil, fmt.Errorf("the log ID=%s version=%d, but the update is for version=%d: %w", log.ID, cur.Version, log.Version, errors.ErrConflict)
+	}
 
 	le := toEntry(log)
 	le.UpdatedAt = timestamppb.Now()
+	le.Version = cur.Version + 1
 
 	key := logKey(le.ID)
 	val := mustMarshal(le)
@@ -148,9 +180,153 @@ func (s *Storage) UpdateLog(ctx context.Context, log *solaris.Log) (*solaris.Log
 	if _, replaced, err = tx.Set(key, val, nil); err != nil || !replaced {
 		return nil, fmt.Errorf("tx.Set(key=%s, val=%s) failed, replaced=%t: %w", key, val, replaced, err)
 	}
+	if err = updateTagsIndex(tx, le.ID, cur.Tags, le.Tags); err != nil {
+		return nil, err
+	}
+	if err = updateSortIndex(tx, le.ID, sortIndexKeys(cur.Log), sortIndexKeys(le.Log)); err != nil {
+		return nil, err
+	}
+	res, err := toLogWithRecords(ctx, tx, le)
+	if err != nil {
+		return nil, err
+	}
 
 	mustCommit(tx)
-	return toLog(le), nil
+	return res, nil
+}
+
+// PatchLogTags implements storage.Logs
+func (s *Storage) PatchLogTags(ctx context.Context, req storage.PatchLogTagsRequest) (*solaris.Log, error) {
+	if len(req.ID) == 0 {
+		return nil, fmt.Errorf("log id must be specified: %w", errors.ErrInvalid)
+	}
+	if err := checkTagsPatch(req.Set, req.Remove); err != nil {
+		return nil, err
+	}
+
+	tx := mustBeginTx(s.db, true)
+	defer mustRollback(tx)
+
+	le, err := s.getLogEntry(tx, logKey(req.ID), true)
+	if err != nil {
+		return nil, err
+	}
+	if req.Version != 0 && req.Version != le.Version {
+		return nil, fmt.Errorf("the log ID=%s version=%d, but the patch is for version=%d: %w", req.ID, le.Version, req.Version, errors.ErrConflict)
+	}
+	if err = s.patchLogEntry(tx, le, req.Set, req.Remove); err != nil {
+		return nil, err
+	}
+	res, err := toLogWithRecords(ctx, tx, le)
+	if err != nil {
+		return nil, err
+	}
+
+	mustCommit(tx)
+	return res, nil
+}
+
+// BulkPatchLogTags implements storage.Logs
+func (s *Storage) BulkPatchLogTags(ctx context.Context, req storage.BulkPatchLogTagsRequest) (*solaris.BulkPatchLogTagsResult, error) {
+	if err := checkTagsPatch(req.Set, req.Remove); err != nil {
+		return nil, err
+	}
+	if len(req.Condition) == 0 {
+		return &solaris.BulkPatchLogTagsResult{}, nil
+	}
+	lc, err := compileLogsCondition(req.Condition)
+	if err != nil {
+		return nil, err
+	}
+	logIDs, err := s.queryLogIDsByCondition(ctx, req.Condition, true)
+	if err != nil {
+		return nil, fmt.Errorf("queryLogIDsByCondition(Cond=%s) failed: %w", req.Condition, err)
+	}
+
+	tx := mustBeginTx(s.db, true)
+	defer mustRollback(tx)
+
+	var patchedIDs []string
+	for _, id := range logIDs {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("context error: %w", ctx.Err())
+		}
+		le, err := s.getLogEntry(tx, logKey(id), true)
+		if errors.Is(err, errors.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		// the log could be changed after the query, so the condition is checked again
+		ok, err := lc.match(ctx, tx, le)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		if err = s.patchLogEntry(tx, le, req.Set, req.Remove); err != nil {
+			return nil, fmt.Errorf("patchLogEntry(ID=%s) failed: %w", id, err)
+		}
+		patchedIDs = append(patchedIDs, id)
+	}
+
+	mustCommit(tx)
+	return &solaris.BulkPatchLogTagsResult{
+		PatchedIDs: patchedIDs,
+	}, nil
+}
+
+// patchLogEntry applies the tags changes to the log entry and stores it, if the tags are changed
+func (s *Storage) patchLogEntry(tx *buntdb.Tx, le logEntry, set map[string]string, remove []string) error {
+	oldTags := maps.Clone(le.Tags)
+	changed := false
+	for _, k := range remove {
+		if _, ok := le.Tags[k]; ok {
+			delete(le.Tags, k)
+			changed = true
+		}
+	}
+	for k, v := range set {
+		if cv, ok := le.Tags[k]; !ok || cv != v {
+			if le.Tags == nil {
+				le.Tags = make(map[string]string)
+			}
+			le.Tags[k] = v
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	oldKeys := sortIndexKeys(le.Log)
+	le.UpdatedAt = timestamppb.Now()
+	le.Version++
+
+	key := logKey(le.ID)
+	val := mustMarshal(le)
+	if _, _, err := tx.Set(key, val, nil); err != nil {
+		return fmt.Errorf("tx.Set(key=%s, val=%s) failed: %w", key, val, err)
+	}
+	if err := updateTagsIndex(tx, le.ID, oldTags, le.Tags); err != nil {
+		return err
+	}
+	return updateSortIndex(tx, le.ID, oldKeys, sortIndexKeys(le.Log))
+}
+
+// checkTagsPatch returns errors.ErrInvalid if the tags patch is empty, or it sets and removes the same tag
+func checkTagsPatch(set map[string]string, remove []string) error {
+	if len(set) == 0 && len(remove) == 0 {
+		return fmt.Errorf("no tags to set or remove: %w", errors.ErrInvalid)
+	}
+	for _, k := range remove {
+		if _, ok := set[k]; ok {
+			return fmt.Errorf("the tag %q cannot be set and removed at the same time: %w", k, errors.ErrInvalid)
+		}
+	}
+	return nil
 }
 
 // QueryLogs implements storage.Logs
@@ -165,7 +341,7 @@ func (s *Storage) QueryLogs(ctx context.Context, qr storage.QueryLogsRequest) (*
 		if err != nil {
 			return nil, fmt.Errorf("queryLogsByIDs(IDs=%v) failed: %w", qr.IDs, err)
 		}
-	} else if len(qr.Condition) > 0 {
+	} else {
 		qRes, err = s.queryLogsByCondition(ctx, qr, !qr.Deleted)
 		if err != nil {
 			return nil, fmt.Errorf("queryLogsByCondition(Cond=%s) failed: %w", qr.Condition, err)
@@ -174,6 +350,108 @@ func (s *Storage) QueryLogs(ctx context.Context, qr storage.QueryLogsRequest) (*
 	return qRes, nil
 }
 
+// GetTagFacets implements storage.Logs. If the request condition is empty, the facets are counted by the
+// tags index, otherwise the tags of the logs matching the condition are counted.
+func (s *Storage) GetTagFacets(ctx context.Context, req storage.TagFacetsRequest) (*solaris.GetTagFacetsResult, error) {
+	tx := mustBeginTx(s.db, false)
+	defer mustRollback(tx)
+
+	counts := make(map[string]int64)
+	if len(req.Condition) == 0 {
+		if err := s.countTagFacetsByIndex(ctx, tx, req.Tag, counts); err != nil {
+			return nil, fmt.Errorf("countTagFacetsByIndex(Tag=%s) failed: %w", req.Tag, err)
+		}
+	} else {
+		lc, err := compileLogsCondition(req.Condition)
+		if err != nil {
+			return nil, err
+		}
+		err = ascendLogsByCondition(ctx, tx, lc, func(le logEntry) bool {
+			if !le.Deleted {
+				countTagFacets(le.Tags, req.Tag, counts)
+			}
+			return true
+		})
+		if err != nil {
+			return nil, fmt.Errorf("ascendLogsByCondition(Cond=%s) failed: %w", req.Condition, err)
+		}
+	}
+
+	values := make([]string, 0, len(counts))
+	for v := range counts {
+		values = append(values, v)
+	}
+	slices.Sort(values)
+	res := &solaris.GetTagFacetsResult{Total: int64(len(values))}
+	if req.Limit > 0 && int64(len(values)) > req.Limit {
+		values = values[:req.Limit]
+	}
+	for _, v := range values {
+		res.Facets = append(res.Facets, &solaris.TagFacet{Value: v, Count: counts[v]})
+	}
+	return res, nil
+}
+
+// countTagFacets adds the tag names, or the values of the tag if it is not empty, of the log tags to the counts
+func countTagFacets(tags map[string]string, tag string, counts map[string]int64) {
+	if len(tag) > 0 {
+		if v := tags[tag]; len(v) > 0 {
+			counts[v]++
+		}
+		return
+	}
+	for k, v := range tags {
+		if len(v) > 0 {
+			counts[k]++
+		}
+	}
+}
+
+// countTagFacetsByIndex adds the tag names, or the values of the tag if it is not empty, of all the logs,
+// not marked deleted, to the counts by the tags index
+func (s *Storage) countTagFacetsByIndex(ctx context.Context, tx *buntdb.Tx, tag string, counts map[string]int64) error {
+	prefix := tagsKeyPrefix(tag)
+	// the logs marked deleted are still in the index, so the logs state is checked once per log
+	alive := make(map[string]bool)
+	var iterErr error
+	iter := func(key, _ string) bool {
+		if ctx.Err() != nil {
+			iterErr = fmt.Errorf("context error: %w", ctx.Err())
+			return false
+		}
+		if !strings.HasPrefix(key, prefix) {
+			return false
+		}
+		k, v, logID, err := parseTagKey(key)
+		if err != nil {
+			iterErr = err
+			return false
+		}
+		ok, found := alive[logID]
+		if !found {
+			_, err = s.getLogEntry(tx, logKey(logID), true)
+			if err != nil && !errors.Is(err, errors.ErrNotExist) {
+				iterErr = err
+				return false
+			}
+			ok = err == nil
+			alive[logID] = ok
+		}
+		switch {
+		case !ok:
+		case len(tag) > 0:
+			counts[v]++
+		default:
+			counts[k]++
+		}
+		return true
+	}
+	if err := tx.AscendGreaterOrEqual("", prefix, iter); err != nil {
+		return fmt.Errorf("iteration failed: %w", err)
+	}
+	return iterErr
+}
+
 // DeleteLogs implements storage.Logs
 func (s *Storage) DeleteLogs(ctx context.Context, req storage.DeleteLogsRequest) (*solaris.DeleteLogsResult, error) {
 	var (
@@ -224,13 +502,20 @@ func (s *Storage) deleteLogsByIDs(ctx context.Context, req storage.DeleteLogsReq
 
 func (s *Storage) deleteLog(ctx context.Context, tx *buntdb.Tx, logID string) error {
 	key := logKey(logID)
-	_, err := tx.Delete(key)
+	val, err := tx.Delete(key)
 	if err != nil && errors.Is(err, buntdb.ErrNotFound) {
 		return errors.ErrNotExist
 	}
 	if err != nil {
 		return fmt.Errorf("tx.Delete(key=%s) failed: %w", key, err)
 	}
+	le := mustUnmarshal[logEntry](val)
+	if err = updateTagsIndex(tx, logID, le.Tags, nil); err != nil {
+		return err
+	}
+	if err = updateSortIndex(tx, logID, sortIndexKeys(le.Log), nil); err != nil {
+		return err
+	}
 	cis, err := getLogChunks(ctx, tx, logID)
 	if err != nil {
 		return fmt.Errorf("getLogChunks(ID=%s) failed: %w", logID, err)
@@ -253,6 +538,7 @@ func (s *Storage) markLogDeleted(tx *buntdb.Tx, logID string) error {
 		return err
 	}
 
+	oldKeys := sortIndexKeys(le.Log)
 	le.Deleted = true
 	le.UpdatedAt = timestamppb.Now()
 
@@ -263,26 +549,100 @@ func (s *Storage) markLogDeleted(tx *buntdb.Tx, logID string) error {
 	if _, replaced, err = tx.Set(key, val, nil); err != nil || !replaced {
 		return fmt.Errorf("tx.Set(key=%s, val=%s) failed, replaced=%t: %w", key, val, replaced, err)
 	}
-	return nil
+	return updateSortIndex(tx, le.ID, oldKeys, sortIndexKeys(le.Log))
 }
 
 func (s *Storage) deleteLogsByCondition(ctx context.Context, req storage.DeleteLogsRequest) (*solaris.DeleteLogsResult, error) {
-	var logIDs []string
-	qRes, err := s.queryLogsByCondition(ctx, storage.QueryLogsRequest{Condition: req.Condition, Limit: 1000}, req.MarkOnly)
-	for err == nil && len(qRes.Logs) > 0 {
-		for _, log := range qRes.Logs {
-			logIDs = append(logIDs, log.ID)
+	logIDs, err := s.queryLogIDsByCondition(ctx, req.Condition, req.MarkOnly)
+	if err != nil {
+		return nil, err
+	}
+	return s.deleteLogsByIDs(ctx, storage.DeleteLogsRequest{IDs: logIDs, MarkOnly: req.MarkOnly})
+}
+
+// UndeleteLogs implements storage.Logs
+func (s *Storage) UndeleteLogs(ctx context.Context, req storage.UndeleteLogsRequest) (*solaris.UndeleteLogsResult, error) {
+	logIDs := req.IDs
+	if len(logIDs) == 0 {
+		if len(req.Condition) == 0 {
+			return &solaris.UndeleteLogsResult{}, nil
+		}
+		var err error
+		if logIDs, err = s.queryLogIDsByCondition(ctx, req.Condition, false); err != nil {
+			return nil, fmt.Errorf("queryLogIDsByCondition(Cond=%s) failed: %w", req.Condition, err)
+		}
+	}
+
+	tx := mustBeginTx(s.db, true)
+	defer mustRollback(tx)
+
+	var undeletedIDs []string
+	for _, id := range logIDs {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("context error: %w", ctx.Err())
 		}
-		qRes.Logs = nil
-		if len(qRes.NextPageID) > 0 {
-			qRes, err = s.queryLogsByCondition(ctx, storage.QueryLogsRequest{Condition: req.Condition,
-				Page: qRes.NextPageID, Limit: 1000}, req.MarkOnly)
+		ok, err := s.unmarkLogDeleted(tx, id)
+		if err != nil && !errors.Is(err, errors.ErrNotExist) {
+			return nil, fmt.Errorf("unmarkLogDeleted(ID=%s) failed: %w", id, err)
 		}
+		if ok {
+			undeletedIDs = append(undeletedIDs, id)
+		}
+	}
+
+	mustCommit(tx)
+	return &solaris.UndeleteLogsResult{
+		UndeletedIDs: undeletedIDs,
+	}, nil
+}
+
+// unmarkLogDeleted clears the deletion mark of the log, it returns true if the log was marked deleted
+// and is not being purged.
+func (s *Storage) unmarkLogDeleted(tx *buntdb.Tx, logID string) (bool, error) {
+	le, err := s.getLogEntry(tx, logKey(logID), false)
+	if err != nil {
+		return false, err
+	}
+	if !le.Deleted || le.Purging {
+		return false, nil
+	}
+
+	oldKeys := sortIndexKeys(le.Log)
+	le.Deleted = false
+	le.UpdatedAt = timestamppb.Now()
+
+	key := logKey(le.ID)
+	val := mustMarshal(le)
+	if _, _, err = tx.Set(key, val, nil); err != nil {
+		return false, fmt.Errorf("tx.Set(key=%s, val=%s) failed: %w", key, val, err)
+	}
+	if err = updateSortIndex(tx, le.ID, oldKeys, sortIndexKeys(le.Log)); err != nil {
+		return false, err
 	}
+	return true, nil
+}
+
+// queryLogIDsByCondition returns the IDs of all the logs matching the condition
+func (s *Storage) queryLogIDsByCondition(ctx context.Context, cond string, skipMarkedDeleted bool) ([]string, error) {
+	lc, err := compileLogsCondition(cond)
 	if err != nil {
 		return nil, err
 	}
-	return s.deleteLogsByIDs(ctx, storage.DeleteLogsRequest{IDs: logIDs, MarkOnly: req.MarkOnly})
+
+	tx := mustBeginTx(s.db, false)
+	defer mustRollback(tx)
+
+	var logIDs []string
+	err = ascendLogsByCondition(ctx, tx, lc, func(le logEntry) bool {
+		if !(skipMarkedDeleted && le.Deleted) {
+			logIDs = append(logIDs, le.ID)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return logIDs, nil
 }
 
 func (s *Storage) queryLogsByIDs(ctx context.Context, qr storage.QueryLogsRequest, skipMarkedDeleted bool) (*solaris.QueryLogsResult, error) {
@@ -294,22 +654,13 @@ func (s *Storage) queryLogsByIDs(ctx context.Context, qr storage.QueryLogsReques
 	logIDs := slices.Clone(qr.IDs)
 	slices.Sort(logIDs)
 
-	startIdx, _ := slices.BinarySearch(logIDs, qr.Page)
-	if startIdx == len(logIDs) {
-		return &solaris.QueryLogsResult{
-			Logs:       nil,
-			NextPageID: "",
-			Total:      int64(len(logIDs)),
-		}, nil
-	}
-
 	tx := mustBeginTx(s.db, false)
 	defer mustRollback(tx)
 
 	var total int64
 	var qLogs []*solaris.Log
 
-	for _, id := range logIDs[startIdx:] {
+	for _, id := range slices.Compact(logIDs) {
 		if ctx.Err() != nil {
 			return nil, fmt.Errorf("context error: %w", ctx.Err())
 		}
@@ -321,7 +672,7 @@ func (s *Storage) queryLogsByIDs(ctx context.Context, qr storage.QueryLogsReques
 			return nil, err
 		}
 		total++
-		if len(qLogs) <= limit { // = for pagination
+		if id >= qr.Page && len(qLogs) <= limit { // = for pagination
 			qLogs = append(qLogs, le.Log)
 		}
 	}
@@ -331,6 +682,9 @@ func (s *Storage) queryLogsByIDs(ctx context.Context, qr storage.QueryLogsReques
 		nextPageID = qLogs[limit].ID
 		qLogs = qLogs[:limit]
 	}
+	if err := setLogsRecords(ctx, tx, qLogs); err != nil {
+		return nil, err
+	}
 	return &solaris.QueryLogsResult{
 		Logs:       qLogs,
 		NextPageID: nextPageID,
@@ -338,14 +692,13 @@ func (s *Storage) queryLogsByIDs(ctx context.Context, qr storage.QueryLogsReques
 	}, nil
 }
 
+// queryLogsByCondition returns the page of the logs matching the qr condition. The logs are walked in the qr order
+// starting from the qr.Page until the page is filled, see walkLogsByCondition. The total number of the matching
+// logs is counted unless it is skipped by the request, because all the candidate logs are checked for it.
 func (s *Storage) queryLogsByCondition(ctx context.Context, qr storage.QueryLogsRequest, skipMarkedDeleted bool) (*solaris.QueryLogsResult, error) {
-	expr, err := ql.Parse(qr.Condition)
-	if err != nil {
-		return nil, fmt.Errorf("condition=%q parse error=%v: %w", qr.Condition, err, errors.ErrInvalid)
-	}
-	tstF, err := ql.BuildExprF(expr, ql.LogsCondDialect)
+	lc, err := compileLogsCondition(qr.Condition)
 	if err != nil {
-		return nil, fmt.Errorf("could not compile condition=%s: %w", qr.Condition, err)
+		return nil, err
 	}
 
 	limit := min(int(qr.Limit), 1000)
@@ -353,43 +706,41 @@ func (s *Storage) queryLogsByCondition(ctx context.Context, qr storage.QueryLogs
 		limit = 50
 	}
 
-	var total int64
-	var iterErr error
+	tx := mustBeginTx(s.db, false)
+	defer mustRollback(tx)
 
 	var qLogs []*solaris.Log
-	iter := func(key, val string) bool {
-		if ctx.Err() != nil {
-			iterErr = fmt.Errorf("context error: %w", ctx.Err())
-			return false
-		}
-		le := mustUnmarshal[logEntry](val)
-		if skipMarkedDeleted && le.Deleted {
-			return true
-		}
-		if tstF(le.Log) {
-			total++
-			if len(qLogs) <= limit { // = for pagination
-				qLogs = append(qLogs, le.Log)
-			}
+	err = walkLogsByCondition(ctx, tx, lc, qr.SortBy, qr.Descending, qr.Page, func(le logEntry) bool {
+		if !(skipMarkedDeleted && le.Deleted) {
+			qLogs = append(qLogs, le.Log)
 		}
-		return true
+		return len(qLogs) <= limit // one more for the next page ID
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	tx := mustBeginTx(s.db, false)
-	defer mustRollback(tx)
-
-	if err = tx.AscendGreaterOrEqual("", logKey(qr.Page), iter); err != nil {
-		return nil, fmt.Errorf("iteration failed: %w", err)
-	}
-	if iterErr != nil {
-		return nil, iterErr
+	var total int64
+	if !qr.SkipTotal {
+		err = ascendLogsByCondition(ctx, tx, lc, func(le logEntry) bool {
+			if !(skipMarkedDeleted && le.Deleted) {
+				total++
+			}
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	var nextPageID string
 	if len(qLogs) > limit {
-		nextPageID = qLogs[limit].ID
+		nextPageID = logSortKey(qLogs[limit], qr.SortBy)
 		qLogs = qLogs[:limit]
 	}
+	if err = setLogsRecords(ctx, tx, qLogs); err != nil {
+		return nil, err
+	}
 	return &solaris.QueryLogsResult{
 		Logs:       qLogs,
 		NextPageID: nextPageID,
@@ -397,25 +748,614 @@ func (s *Storage) queryLogsByCondition(ctx context.Context, qr storage.QueryLogs
 	}, nil
 }
 
-func (s *Storage) getLogEntry(tx *buntdb.Tx, key string, skipMarkedDeleted bool) (logEntry, error) {
-	val, err := getValue(tx, key)
-	if err != nil {
-		return logEntry{}, err
-	}
-	var le logEntry
-	if le = mustUnmarshal[logEntry](val); skipMarkedDeleted && le.Deleted {
-		return logEntry{}, errors.ErrNotExist
+// logSortKey returns the string, which defines the log position in the sortBy order. The key is the log ID,
+// prefixed by the fixed width value of the sortBy field, so the keys can be compared as strings.
+func logSortKey(log *solaris.Log, sortBy solaris.LogsSortBy) string {
+	switch sortBy {
+	case solaris.LogsSortBy_LOGS_SORT_BY_CREATED_AT:
+		return fmt.Sprintf("%020d%s", log.CreatedAt.AsTime().UnixNano(), log.ID)
+	case solaris.LogsSortBy_LOGS_SORT_BY_UPDATED_AT:
+		return fmt.Sprintf("%020d%s", log.UpdatedAt.AsTime().UnixNano(), log.ID)
 	}
-	return le, nil
+	return log.ID
 }
 
-func logKey(id string) string {
-	return fmt.Sprintf("/logs/%s", id)
+// ascendLogs calls f for the log entries in the ascending order of the log IDs, starting from the fromID
+// (inclusive), until f returns false. The chunk entries are skipped.
+func ascendLogs(ctx context.Context, tx *buntdb.Tx, fromID string, f func(le logEntry) bool) error {
+	var iterErr error
+	iter := func(key, val string) bool {
+		if ctx.Err() != nil {
+			iterErr = fmt.Errorf("context error: %w", ctx.Err())
+			return false
+		}
+		if !strings.HasPrefix(key, logKey("")) {
+			return false
+		}
+		if !isLogKey(key) {
+			return true
+		}
+		return f(mustUnmarshal[logEntry](val))
+	}
+	if err := tx.AscendGreaterOrEqual("", logKey(fromID), iter); err != nil {
+		return fmt.Errorf("iteration failed: %w", err)
+	}
+	return iterErr
 }
 
-// ===================================== chunks =====================================
-
-// GetLastChunk implements logfs.LogsMetaStorage
+// descendLogs calls f for the log entries in the descending order of the log IDs, starting from the fromID
+// (inclusive), or from the last log if the fromID is empty, until f returns false. The chunk entries are skipped.
+func descendLogs(ctx context.Context, tx *buntdb.Tx, fromID string, f func(le logEntry) bool) error {
+	if len(fromID) == 0 {
+		fromID = logsMaxID
+	}
+	var iterErr error
+	iter := func(key, val string) bool {
+		if ctx.Err() != nil {
+			iterErr = fmt.Errorf("context error: %w", ctx.Err())
+			return false
+		}
+		if !strings.HasPrefix(key, logKey("")) {
+			return false
+		}
+		if !isLogKey(key) {
+			return true
+		}
+		return f(mustUnmarshal[logEntry](val))
+	}
+	if err := tx.DescendLessOrEqual("", logKey(fromID), iter); err != nil {
+		return fmt.Errorf("iteration failed: %w", err)
+	}
+	return iterErr
+}
+
+// ascendLogsByCondition calls f for the log entries matching the lc, in the ascending order of the log IDs,
+// until f returns false. If the lc allows, the candidate logs are selected by the tags index, otherwise
+// all the logs are scanned.
+func ascendLogsByCondition(ctx context.Context, tx *buntdb.Tx, lc logsCond, f func(le logEntry) bool) error {
+	return walkLogsByCondition(ctx, tx, lc, solaris.LogsSortBy_LOGS_SORT_BY_ID, false, "", f)
+}
+
+// walkLogsByCondition calls f for the log entries matching the lc in the sortBy order, starting from the log
+// with the page sort key (inclusive, see logSortKey), until f returns false. The logs are walked by their keys
+// or by the sort index, so the logs after the one f stops at are not read. If the lc allows, the candidate logs
+// are selected by the tags index, and the other logs are skipped without being read. The candidates are read
+// and sorted in memory for the order other than the IDs one, unless there are more than maxSortedCandidates
+// of them, then the sort index is walked and the not candidate logs are skipped.
+func walkLogsByCondition(ctx context.Context, tx *buntdb.Tx, lc logsCond, sortBy solaris.LogsSortBy, descending bool,
+	page string, f func(le logEntry) bool) error {
+	var matchErr error
+	matchF := func(le logEntry) bool {
+		ok, err := lc.match(ctx, tx, le)
+		if err != nil {
+			matchErr = err
+			return false
+		}
+		return !ok || f(le)
+	}
+
+	var err error
+	plan, ok := buildLogsPlan(lc.expr)
+	if !ok {
+		switch {
+		case sortBy != solaris.LogsSortBy_LOGS_SORT_BY_ID:
+			err = walkSortIndex(ctx, tx, sortBy, descending, page, nil, matchF)
+		case descending:
+			err = descendLogs(ctx, tx, page, matchF)
+		default:
+			err = ascendLogs(ctx, tx, page, matchF)
+		}
+		if err != nil {
+			return err
+		}
+		return matchErr
+	}
+
+	logIDs, err := plan.execute(func(l *logsLookup) ([]string, error) {
+		return lookupTagsIndex(ctx, tx, l)
+	})
+	if err != nil {
+		return err
+	}
+	switch {
+	case sortBy != solaris.LogsSortBy_LOGS_SORT_BY_ID && len(logIDs) <= maxSortedCandidates:
+		err = walkSortedLogIDs(ctx, tx, logIDs, sortBy, descending, page, matchF)
+	case sortBy != solaris.LogsSortBy_LOGS_SORT_BY_ID:
+		// too many candidates to sort them in memory, so the sort index is walked, and the other logs
+		// are skipped. The index keys are scanned up to the page end, which may be the whole index.
+		err = walkSortIndex(ctx, tx, sortBy, descending, page, func(id string) bool {
+			_, found := slices.BinarySearch(logIDs, id)
+			return found
+		}, matchF)
+	default:
+		err = walkLogIDs(ctx, tx, logIDs, descending, page, matchF)
+	}
+	if err != nil {
+		return err
+	}
+	return matchErr
+}
+
+// maxSortedCandidates defines how many candidate logs, selected by the tags index, may be read and
+// sorted in memory for the query in the order other than the IDs one
+const maxSortedCandidates = 1000
+
+// walkSortedLogIDs calls f for the log entries of the logIDs in the sortBy order, ascending or descending,
+// starting from the log with the fromKey sort key (inclusive), until f returns false. All the logIDs
+// entries are read to be sorted, the logs which don't exist are skipped.
+func walkSortedLogIDs(ctx context.Context, tx *buntdb.Tx, logIDs []string, sortBy solaris.LogsSortBy, descending bool,
+	fromKey string, f func(le logEntry) bool) error {
+	les := make([]logEntry, 0, len(logIDs))
+	keys := make(map[string]string, len(logIDs))
+	for _, id := range logIDs {
+		if ctx.Err() != nil {
+			return fmt.Errorf("context error: %w", ctx.Err())
+		}
+		val, err := getValue(tx, logKey(id))
+		if errors.Is(err, errors.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		le := mustUnmarshal[logEntry](val)
+		keys[le.ID] = logSortKey(le.Log, sortBy)
+		les = append(les, le)
+	}
+	slices.SortFunc(les, func(a, b logEntry) int {
+		return strings.Compare(keys[a.ID], keys[b.ID])
+	})
+
+	idx, inc := 0, 1
+	if len(fromKey) > 0 {
+		var found bool
+		idx, found = slices.BinarySearchFunc(les, fromKey, func(le logEntry, key string) int {
+			return strings.Compare(keys[le.ID], key)
+		})
+		if descending && !found {
+			idx--
+		}
+	} else if descending {
+		idx = len(les) - 1
+	}
+	if descending {
+		inc = -1
+	}
+	for ; idx >= 0 && idx < len(les); idx += inc {
+		if !f(les[idx]) {
+			break
+		}
+	}
+	return nil
+}
+
+// walkLogIDs calls f for the log entries of the sorted logIDs in the ascending or descending order, starting
+// from the fromID (inclusive), until f returns false. The logs which don't exist are skipped.
+func walkLogIDs(ctx context.Context, tx *buntdb.Tx, logIDs []string, descending bool, fromID string, f func(le logEntry) bool) error {
+	idx, inc := 0, 1
+	if len(fromID) > 0 {
+		var found bool
+		idx, found = slices.BinarySearch(logIDs, fromID)
+		if descending && !found {
+			idx--
+		}
+	} else if descending {
+		idx = len(logIDs) - 1
+	}
+	if descending {
+		inc = -1
+	}
+	for ; idx >= 0 && idx < len(logIDs); idx += inc {
+		if ctx.Err() != nil {
+			return fmt.Errorf("context error: %w", ctx.Err())
+		}
+		val, err := getValue(tx, logKey(logIDs[idx]))
+		if errors.Is(err, errors.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if !f(mustUnmarshal[logEntry](val)) {
+			break
+		}
+	}
+	return nil
+}
+
+// compileLogsCondition parses the cond and compiles it in the LogsCondDialect
+func compileLogsCondition(cond string) (logsCond, error) {
+	expr, err := ql.Parse(cond)
+	if err != nil {
+		return logsCond{}, fmt.Errorf("condition=%q parse error=%v: %w", cond, err, errors.ErrInvalid)
+	}
+	tstF, err := ql.BuildExprF(expr, ql.LogsCondDialect)
+	if err != nil {
+		return logsCond{}, fmt.Errorf("could not compile condition=%s: %w", cond, err)
+	}
+	return logsCond{expr: expr, tstF: tstF, records: expr.HasParam("records")}, nil
+}
+
+// match returns whether the log entry matches the condition. If the condition refers to the log records
+// number, it is calculated by the log chunks and set to the log.
+func (lc logsCond) match(ctx context.Context, tx *buntdb.Tx, le logEntry) (bool, error) {
+	if !lc.records {
+		return lc.tstF(le.Log), nil
+	}
+	records, err := logRecords(ctx, tx, le.ID)
+	if err != nil {
+		return false, err
+	}
+	// the entry may be stored later (e.g. patched), so the calculated
+	// number of records is set to the copy of the log only
+	return lc.tstF(&solaris.Log{ID: le.ID, Tags: le.Tags, CreatedAt: le.CreatedAt, UpdatedAt: le.UpdatedAt,
+		Records: records, Retention: le.Retention, Version: le.Version}), nil
+}
+
+func (s *Storage) getLogEntry(tx *buntdb.Tx, key string, skipMarkedDeleted bool) (logEntry, error) {
+	val, err := getValue(tx, key)
+	if err != nil {
+		return logEntry{}, err
+	}
+	var le logEntry
+	if le = mustUnmarshal[logEntry](val); skipMarkedDeleted && le.Deleted {
+		return logEntry{}, errors.ErrNotExist
+	}
+	return le, nil
+}
+
+// GetLogs implements logfs.RetentionStorage
+func (s *Storage) GetLogs(ctx context.Context, afterID string, limit int, ownRetentionOnly bool) ([]*solaris.Log, error) {
+	tx := mustBeginTx(s.db, false)
+	defer mustRollback(tx)
+
+	var logs []*solaris.Log
+	err := ascendLogs(ctx, tx, afterID, func(le logEntry) bool {
+		if !le.Deleted && le.ID != afterID && (!ownRetentionOnly || le.Retention != nil) {
+			logs = append(logs, le.Log)
+		}
+		return len(logs) < limit
+	})
+	if err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
+
+// GetDeletedLogs implements logfs.DeletedLogsStorage
+func (s *Storage) GetDeletedLogs(ctx context.Context, afterID string, deletedBefore time.Time, limit int) ([]*solaris.Log, error) {
+	tx := mustBeginTx(s.db, false)
+	defer mustRollback(tx)
+
+	var logs []*solaris.Log
+	err := ascendLogs(ctx, tx, afterID, func(le logEntry) bool {
+		if le.Deleted && le.ID != afterID && le.UpdatedAt.AsTime().Before(deletedBefore) {
+			logs = append(logs, le.Log)
+		}
+		return len(logs) < limit
+	})
+	if err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
+
+// BeginPurgeLog implements logfs.DeletedLogsStorage
+func (s *Storage) BeginPurgeLog(ctx context.Context, logID string) ([]logfs.ChunkInfo, error) {
+	tx := mustBeginTx(s.db, true)
+	defer mustRollback(tx)
+
+	le, err := s.getLogEntry(tx, logKey(logID), false)
+	if err != nil {
+		return nil, fmt.Errorf("getLogEntry(ID=%s) failed: %w", logID, err)
+	}
+	if !le.Deleted {
+		return nil, fmt.Errorf("the log ID=%s is not marked deleted: %w", logID, errors.ErrConflict)
+	}
+	cis, err := getLogChunks(ctx, tx, logID)
+	if err != nil {
+		return nil, fmt.Errorf("getLogChunks(ID=%s) failed: %w", logID, err)
+	}
+	if !le.Purging {
+		le.Purging = true
+		key := logKey(logID)
+		val := mustMarshal(le)
+		if _, _, err = tx.Set(key, val, nil); err != nil {
+			return nil, fmt.Errorf("tx.Set(key=%s, val=%s) failed: %w", key, val, err)
+		}
+	}
+
+	mustCommit(tx)
+	return cis, nil
+}
+
+// PurgeLog implements logfs.DeletedLogsStorage
+func (s *Storage) PurgeLog(ctx context.Context, logID string) error {
+	tx := mustBeginTx(s.db, true)
+	defer mustRollback(tx)
+
+	le, err := s.getLogEntry(tx, logKey(logID), false)
+	if err != nil {
+		return fmt.Errorf("getLogEntry(ID=%s) failed: %w", logID, err)
+	}
+	if !le.Deleted {
+		return fmt.Errorf("the log ID=%s is not marked deleted: %w", logID, errors.ErrConflict)
+	}
+	if err = s.deleteLog(ctx, tx, logID); err != nil {
+		return fmt.Errorf("deleteLog(ID=%s) failed: %w", logID, err)
+	}
+
+	mustCommit(tx)
+	return nil
+}
+
+// logsMaxID is greater than any log ID, so the logKey(logsMaxID) is greater than any log key
+const logsMaxID = "~"
+
+func logKey(id string) string {
+	return fmt.Sprintf("/logs/%s", id)
+}
+
+// ===================================== indexes =====================================
+
+// indexBuildBatchSize defines how many logs are indexed in one transaction when an index is built
+const indexBuildBatchSize = 1000
+
+// buildIndex builds the index with the version key indexKey, if the key doesn't exist, by calling
+// the update for every log. The logs are indexed in batches, each batch in its own transaction, so
+// the logs are not loaded into memory all at once. The index key is set with the last batch, so if
+// the build is interrupted, it is started over on the next run. The update must be idempotent.
+func (s *Storage) buildIndex(ctx context.Context, indexKey, name string, update func(tx *buntdb.Tx, le logEntry) error) error {
+	tx := mustBeginTx(s.db, false)
+	_, err := getValue(tx, indexKey)
+	mustRollback(tx)
+	if err == nil {
+		return nil
+	}
+
+	total := 0
+	afterID := ""
+	for {
+		ids, err := s.buildIndexBatch(ctx, indexKey, afterID, update)
+		if err != nil {
+			return fmt.Errorf("could not build the %s index: %w", name, err)
+		}
+		total += len(ids)
+		if len(ids) < indexBuildBatchSize {
+			break
+		}
+		afterID = ids[len(ids)-1]
+	}
+	s.logger.Infof("The %s index is built for %d logs", name, total)
+	return nil
+}
+
+// buildIndexBatch calls the update for up to indexBuildBatchSize logs with the IDs greater than afterID
+// in one transaction, and returns the IDs of the logs updated. If there are no more logs, the index key
+// is set in the same transaction.
+func (s *Storage) buildIndexBatch(ctx context.Context, indexKey, afterID string, update func(tx *buntdb.Tx, le logEntry) error) ([]string, error) {
+	tx := mustBeginTx(s.db, true)
+	defer mustRollback(tx)
+
+	// the tx cannot be modified while iterating, so the batch is read first
+	var les []logEntry
+	if err := ascendLogs(ctx, tx, afterID, func(le logEntry) bool {
+		if le.ID != afterID {
+			les = append(les, le)
+		}
+		return len(les) < indexBuildBatchSize
+	}); err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(les))
+	for _, le := range les {
+		if err := update(tx, le); err != nil {
+			return nil, err
+		}
+		ids = append(ids, le.ID)
+	}
+	if len(les) < indexBuildBatchSize {
+		if _, _, err := tx.Set(indexKey, "1", nil); err != nil {
+			return nil, fmt.Errorf("tx.Set(key=%s) failed: %w", indexKey, err)
+		}
+	}
+	mustCommit(tx)
+	return ids, nil
+}
+
+// ===================================== tags index =====================================
+
+// tagsIndexKey is the key of the tags index version, the key exists if the index is built
+const tagsIndexKey = "/index/tags"
+
+// buildTagsIndex builds the tags index for the logs, if it is not built yet
+func (s *Storage) buildTagsIndex(ctx context.Context) error {
+	return s.buildIndex(ctx, tagsIndexKey, "tags", func(tx *buntdb.Tx, le logEntry) error {
+		return updateTagsIndex(tx, le.ID, nil, le.Tags)
+	})
+}
+
+// updateTagsIndex replaces the tags index entries of the log oldTags with the newTags ones.
+// The tags with the empty values are not indexed.
+func updateTagsIndex(tx *buntdb.Tx, logID string, oldTags, newTags map[string]string) error {
+	for k, v := range oldTags {
+		if nv, ok := newTags[k]; (ok && nv == v) || v == "" {
+			continue
+		}
+		key := tagKey(k, v, logID)
+		if _, err := tx.Delete(key); err != nil && !errors.Is(err, buntdb.ErrNotFound) {
+			return fmt.Errorf("tx.Delete(key=%s) failed: %w", key, err)
+		}
+	}
+	for k, v := range newTags {
+		if ov, ok := oldTags[k]; (ok && ov == v) || v == "" {
+			continue
+		}
+		key := tagKey(k, v, logID)
+		if _, _, err := tx.Set(key, "", nil); err != nil {
+			return fmt.Errorf("tx.Set(key=%s) failed: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// lookupTagsIndex returns the IDs of the logs selected by the lookup l
+func lookupTagsIndex(ctx context.Context, tx *buntdb.Tx, l *logsLookup) ([]string, error) {
+	if l.tag == "" {
+		return slices.Clone(l.values), nil
+	}
+	var iterErr error
+	var logIDs []string
+	for _, v := range l.values {
+		prefix := tagKey(l.tag, v, "")
+		iter := func(key, _ string) bool {
+			if ctx.Err() != nil {
+				iterErr = fmt.Errorf("context error: %w", ctx.Err())
+				return false
+			}
+			if !strings.HasPrefix(key, prefix) {
+				return false
+			}
+			logIDs = append(logIDs, key[len(prefix):])
+			return true
+		}
+		if err := tx.AscendGreaterOrEqual("", prefix, iter); err != nil {
+			return nil, fmt.Errorf("iteration failed: %w", err)
+		}
+		if iterErr != nil {
+			return nil, iterErr
+		}
+	}
+	return logIDs, nil
+}
+
+func tagKey(tag, value, logID string) string {
+	return fmt.Sprintf("/tags/%s/%s/%s", url.PathEscape(tag), url.PathEscape(value), logID)
+}
+
+// tagsKeyPrefix returns the prefix of the tags index keys of the tag, or of all the tags if the tag is empty
+func tagsKeyPrefix(tag string) string {
+	if len(tag) == 0 {
+		return "/tags/"
+	}
+	return fmt.Sprintf("/tags/%s/", url.PathEscape(tag))
+}
+
+// parseTagKey returns the tag name, the tag value and the log ID of the tags index key
+func parseTagKey(key string) (string, string, string, error) {
+	parts := strings.SplitN(strings.TrimPrefix(key, tagsKeyPrefix("")), "/", 3)
+	if len(parts) != 3 {
+		return "", "", "", fmt.Errorf("malformed tags index key=%s: %w", key, errors.ErrInternal)
+	}
+	tag, err := url.PathUnescape(parts[0])
+	if err != nil {
+		return "", "", "", fmt.Errorf("malformed tag in the tags index key=%s: %w", key, errors.ErrInternal)
+	}
+	value, err := url.PathUnescape(parts[1])
+	if err != nil {
+		return "", "", "", fmt.Errorf("malformed value in the tags index key=%s: %w", key, errors.ErrInternal)
+	}
+	return tag, value, parts[2], nil
+}
+
+// ===================================== sort index =====================================
+
+// sortIndexKey is the key of the sort index version, the key exists if the index is built
+const sortIndexKey = "/index/sort"
+
+// sortIndexFields contains the orders the sort index is kept for, the IDs order is the order of the log keys
+var sortIndexFields = []solaris.LogsSortBy{solaris.LogsSortBy_LOGS_SORT_BY_CREATED_AT, solaris.LogsSortBy_LOGS_SORT_BY_UPDATED_AT}
+
+// buildSortIndex builds the sort index for the logs, if it is not built yet
+func (s *Storage) buildSortIndex(ctx context.Context) error {
+	return s.buildIndex(ctx, sortIndexKey, "sort", func(tx *buntdb.Tx, le logEntry) error {
+		return updateSortIndex(tx, le.ID, nil, sortIndexKeys(le.Log))
+	})
+}
+
+// updateSortIndex replaces the sort index entries of the log oldKeys with the newKeys ones,
+// see sortIndexKeys
+func updateSortIndex(tx *buntdb.Tx, logID string, oldKeys, newKeys []string) error {
+	for _, key := range oldKeys {
+		if slices.Contains(newKeys, key) {
+			continue
+		}
+		if _, err := tx.Delete(key); err != nil && !errors.Is(err, buntdb.ErrNotFound) {
+			return fmt.Errorf("tx.Delete(key=%s) failed: %w", key, err)
+		}
+	}
+	for _, key := range newKeys {
+		if slices.Contains(oldKeys, key) {
+			continue
+		}
+		if _, _, err := tx.Set(key, logID, nil); err != nil {
+			return fmt.Errorf("tx.Set(key=%s) failed: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// sortIndexKeys returns the sort index keys of the log, the log fields the keys depend on may be changed
+// after the call, so the keys are used for the index update then
+func sortIndexKeys(log *solaris.Log) []string {
+	keys := make([]string, len(sortIndexFields))
+	for i, sortBy := range sortIndexFields {
+		keys[i] = sortKey(sortBy, logSortKey(log, sortBy))
+	}
+	return keys
+}
+
+// walkSortIndex calls f for the log entries in the sortBy order, ascending or descending, starting from the log
+// with the fromKey sort key (inclusive), or from the first log in the order if the fromKey is empty, until f
+// returns false. If the filterF is not nil, the logs with the IDs it doesn't accept are skipped without being read.
+func walkSortIndex(ctx context.Context, tx *buntdb.Tx, sortBy solaris.LogsSortBy, descending bool, fromKey string,
+	filterF func(id string) bool, f func(le logEntry) bool) error {
+	prefix := sortKey(sortBy, "")
+	var iterErr error
+	iter := func(key, id string) bool {
+		if ctx.Err() != nil {
+			iterErr = fmt.Errorf("context error: %w", ctx.Err())
+			return false
+		}
+		if !strings.HasPrefix(key, prefix) {
+			return false
+		}
+		if filterF != nil && !filterF(id) {
+			return true
+		}
+		val, err := getValue(tx, logKey(id))
+		if errors.Is(err, errors.ErrNotExist) {
+			return true
+		}
+		if err != nil {
+			iterErr = err
+			return false
+		}
+		return f(mustUnmarshal[logEntry](val))
+	}
+	var err error
+	if descending {
+		if len(fromKey) == 0 {
+			fromKey = logsMaxID
+		}
+		err = tx.DescendLessOrEqual("", sortKey(sortBy, fromKey), iter)
+	} else {
+		err = tx.AscendGreaterOrEqual("", sortKey(sortBy, fromKey), iter)
+	}
+	if err != nil {
+		return fmt.Errorf("iteration failed: %w", err)
+	}
+	return iterErr
+}
+
+// sortKey returns the sort index key of the log by its logSortKey for the sortBy order
+func sortKey(sortBy solaris.LogsSortBy, logSortKey string) string {
+	return fmt.Sprintf("/sort/%d/%s", sortBy, logSortKey)
+}
+
+// ===================================== chunks =====================================
+
+// GetLastChunk implements logfs.LogsMetaStorage
 func (s *Storage) GetLastChunk(ctx context.Context, logID string) (logfs.ChunkInfo, error) {
 	tx := mustBeginTx(s.db, false)
 	defer mustRollback(tx)
@@ -453,6 +1393,46 @@ func (s *Storage) UpsertChunkInfos(ctx context.Context, logID string, cis []logf
 	tx := mustBeginTx(s.db, true)
 	defer mustRollback(tx)
 
+	if err := s.upsertChunkInfos(ctx, tx, logID, cis); err != nil {
+		return err
+	}
+
+	mustCommit(tx)
+	return nil
+}
+
+// UpsertLogsChunkInfos implements logfs.LogsMetaStorage
+func (s *Storage) UpsertLogsChunkInfos(ctx context.Context, lcis map[string][]logfs.ChunkInfo) error {
+	tx := mustBeginTx(s.db, true)
+	defer mustRollback(tx)
+
+	for logID, cis := range lcis {
+		if err := s.upsertChunkInfos(ctx, tx, logID, cis); err != nil {
+			return err
+		}
+	}
+
+	mustCommit(tx)
+	return nil
+}
+
+// DeleteChunkInfos implements logfs.LogsMetaStorage
+func (s *Storage) DeleteChunkInfos(ctx context.Context, logID string, chunkIDs []string) error {
+	tx := mustBeginTx(s.db, true)
+	defer mustRollback(tx)
+
+	for _, cID := range chunkIDs {
+		key := chnkKey(logID, cID)
+		if _, err := tx.Delete(key); err != nil && !errors.Is(err, buntdb.ErrNotFound) {
+			return fmt.Errorf("tx.Delete(key=%s) failed: %w", key, err)
+		}
+	}
+
+	mustCommit(tx)
+	return nil
+}
+
+func (s *Storage) upsertChunkInfos(ctx context.Context, tx *buntdb.Tx, logID string, cis []logfs.ChunkInfo) error {
 	if _, err := s.getLogEntry(tx, logKey(logID), true); err != nil {
 		return fmt.Errorf("getLogEntry(ID=%s) failed: %w", logID, err)
 	}
@@ -472,8 +1452,6 @@ func (s *Storage) UpsertChunkInfos(ctx context.Context, logID string, cis []logf
 			return fmt.Errorf("tx.Set(key=%s, val=%s) failed: %w", key, val, err)
 		}
 	}
-
-	mustCommit(tx)
 	return nil
 }
 
@@ -497,6 +1475,11 @@ func getLogChunks(ctx context.Context, tx *buntdb.Tx, logID string) ([]logfs.Chu
 	return cis, nil
 }
 
+// isLogKey returns true if the key is the log key, but not the log chunk key
+func isLogKey(key string) bool {
+	return !strings.Contains(key[len(logKey("")):], "/")
+}
+
 func chnkKey(logID, chnkID string) string {
 	return fmt.Sprintf("%s/chunks/%s", logKey(logID), chnkID)
 }
@@ -551,10 +1534,44 @@ func mustUnmarshal[T any](val string) T {
 	return *e
 }
 
+// toEntry returns the log entry for the log. The records number is not stored with the log, but
+// calculated by the log chunks, so the log value is ignored.
 func toEntry(log *solaris.Log) logEntry {
-	return logEntry{Log: log}
+	le := logEntry{Log: log}
+	le.Records = 0
+	return le
 }
 
 func toLog(le logEntry) *solaris.Log {
 	return le.Log
 }
+
+// toLogWithRecords returns the log of the entry with the records number calculated by the log chunks
+func toLogWithRecords(ctx context.Context, tx *buntdb.Tx, le logEntry) (*solaris.Log, error) {
+	log := toLog(le)
+	if err := setLogsRecords(ctx, tx, []*solaris.Log{log}); err != nil {
+		return nil, err
+	}
+	return log, nil
+}
+
+// setLogsRecords sets the records number of the logs calculated by their chunks
+func setLogsRecords(ctx context.Context, tx *buntdb.Tx, logs []*solaris.Log) error {
+	for _, log := range logs {
+		records, err := logRecords(ctx, tx, log.ID)
+		if err != nil {
+			return err
+		}
+		log.Records = records
+	}
+	return nil
+}
+
+// logRecords returns the number of the log records, which are not truncated, by the log chunks
+func logRecords(ctx context.Context, tx *buntdb.Tx, logID string) (int64, error) {
+	cis, err := getLogChunks(ctx, tx, logID)
+	if err != nil {
+		return 0, err
+	}
+	return logfs.VisibleRecords(cis), nil
+}
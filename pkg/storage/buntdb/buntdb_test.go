@@ -23,7 +23,10 @@ import (
 	"github.com/stretchr/testify/assert"
 	"maps"
 	"math/rand"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestStorage_CreateLog(t *testing.T) {
@@ -59,6 +62,171 @@ func TestStorage_UpdateLog(t *testing.T) {
 	assert.True(t, maps.Equal(log2.Tags, log1.Tags))
 }
 
+func TestStorage_UpdateLogVersion(t *testing.T) {
+	ctx := context.Background()
+	s, err := getStorage(ctx)
+	assert.Nil(t, err)
+
+	log, err := s.CreateLog(ctx, &solaris.Log{Tags: map[string]string{"tag1": "val1"}})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), log.Version)
+
+	upd1 := &solaris.Log{ID: log.ID, Tags: map[string]string{"tag1": "val2"}, Version: 1}
+	upd2 := &solaris.Log{ID: log.ID, Tags: map[string]string{"tag2": "val2"}, Version: 1}
+	log, err = s.UpdateLog(ctx, upd1)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), log.Version)
+
+	// the second update is based on the stale version
+	_, err = s.UpdateLog(ctx, upd2)
+	assert.True(t, errors.Is(err, errors.ErrConflict))
+	log, err = s.GetLogByID(ctx, log.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"tag1": "val2"}, log.Tags)
+	assert.Equal(t, int64(2), log.Version)
+
+	// the version is not checked if it is not provided
+	log, err = s.UpdateLog(ctx, &solaris.Log{ID: log.ID, Tags: map[string]string{"tag3": "val3"}})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), log.Version)
+}
+
+func TestStorage_PatchLogTags(t *testing.T) {
+	ctx := context.Background()
+	s, err := getStorage(ctx)
+	assert.Nil(t, err)
+
+	log, err := s.CreateLog(ctx, &solaris.Log{Tags: map[string]string{"tag1": "val1", "tag2": "val2"}})
+	assert.Nil(t, err)
+
+	_, err = s.PatchLogTags(ctx, storage.PatchLogTagsRequest{ID: log.ID})
+	assert.True(t, errors.Is(err, errors.ErrInvalid))
+	_, err = s.PatchLogTags(ctx, storage.PatchLogTagsRequest{ID: log.ID, Set: map[string]string{"tag1": "a"}, Remove: []string{"tag1"}})
+	assert.True(t, errors.Is(err, errors.ErrInvalid))
+	_, err = s.PatchLogTags(ctx, storage.PatchLogTagsRequest{ID: "unknown", Set: map[string]string{"tag1": "a"}})
+	assert.True(t, errors.Is(err, errors.ErrNotExist))
+
+	plog, err := s.PatchLogTags(ctx, storage.PatchLogTagsRequest{ID: log.ID, Set: map[string]string{"tag1": "val11", "tag3": "val3"},
+		Remove: []string{"tag2", "tag4"}, Version: log.Version})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"tag1": "val11", "tag3": "val3"}, plog.Tags)
+	assert.Equal(t, log.Version+1, plog.Version)
+
+	_, err = s.PatchLogTags(ctx, storage.PatchLogTagsRequest{ID: log.ID, Set: map[string]string{"tag1": "a"}, Version: log.Version})
+	assert.True(t, errors.Is(err, errors.ErrConflict))
+
+	// nothing is changed, so the version stays the same
+	plog, err = s.PatchLogTags(ctx, storage.PatchLogTagsRequest{ID: log.ID, Set: map[string]string{"tag1": "val11"}})
+	assert.Nil(t, err)
+	assert.Equal(t, log.Version+1, plog.Version)
+
+	glog, err := s.GetLogByID(ctx, log.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, plog.Tags, glog.Tags)
+}
+
+func TestStorage_BulkPatchLogTags(t *testing.T) {
+	ctx := context.Background()
+	s, err := getStorage(ctx)
+	assert.Nil(t, err)
+
+	var logs []*solaris.Log
+	for i := 0; i < 5; i++ {
+		log, err := s.CreateLog(ctx, &solaris.Log{Tags: map[string]string{"crm": "old", "n": fmt.Sprintf("%d", i)}})
+		assert.Nil(t, err)
+		logs = append(logs, log)
+	}
+	err = s.UpsertChunkInfos(ctx, logs[0].ID, []logfs.ChunkInfo{{ID: "c1", RecordsCount: 1}})
+	assert.Nil(t, err)
+	_, err = s.DeleteLogs(ctx, storage.DeleteLogsRequest{IDs: []string{logs[4].ID}, MarkOnly: true})
+	assert.Nil(t, err)
+
+	res, err := s.BulkPatchLogTags(ctx, storage.BulkPatchLogTagsRequest{Condition: "tag('crm') = 'old' and tag('n') < '3'",
+		Set: map[string]string{"crm": "new"}, Remove: []string{"n"}})
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{logs[0].ID, logs[1].ID, logs[2].ID}, res.PatchedIDs)
+
+	qr, err := s.QueryLogs(ctx, storage.QueryLogsRequest{Condition: "tag('crm') = 'new'"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), qr.Total)
+	for _, log := range qr.Logs {
+		assert.Equal(t, map[string]string{"crm": "new"}, log.Tags)
+		assert.Equal(t, int64(2), log.Version)
+	}
+	qr, err = s.QueryLogs(ctx, storage.QueryLogsRequest{Condition: "tag('crm') = 'old'", Deleted: true})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), qr.Total)
+
+	res, err = s.BulkPatchLogTags(ctx, storage.BulkPatchLogTagsRequest{Condition: "tag('crm') = 'old'"})
+	assert.True(t, errors.Is(err, errors.ErrInvalid))
+	res, err = s.BulkPatchLogTags(ctx, storage.BulkPatchLogTagsRequest{Condition: "tag('crm') = 'none'", Set: map[string]string{"a": "b"}})
+	assert.Nil(t, err)
+	assert.Empty(t, res.PatchedIDs)
+}
+
+func TestStorage_BulkPatchLogTagsByRecords(t *testing.T) {
+	ctx := context.Background()
+	s, err := getStorage(ctx)
+	assert.Nil(t, err)
+
+	var logs []*solaris.Log
+	for i := 0; i < 3; i++ {
+		log, err := s.CreateLog(ctx, &solaris.Log{Tags: map[string]string{"n": fmt.Sprintf("%d", i)}})
+		assert.Nil(t, err)
+		assert.Nil(t, s.UpsertChunkInfos(ctx, log.ID, []logfs.ChunkInfo{{ID: "c1", RecordsCount: 10 * (i + 1)}}))
+		logs = append(logs, log)
+	}
+
+	res, err := s.BulkPatchLogTags(ctx, storage.BulkPatchLogTagsRequest{Condition: "records > 10", Set: map[string]string{"big": "true"}})
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{logs[1].ID, logs[2].ID}, res.PatchedIDs)
+
+	stored, err := s.GetLogs(ctx, "", 10, false)
+	assert.Nil(t, err)
+	assert.Len(t, stored, 3)
+	for _, log := range stored {
+		assert.Equal(t, int64(0), log.Records)
+	}
+	qr, err := s.QueryLogs(ctx, storage.QueryLogsRequest{Condition: "tag('big') = 'true' AND records > 10"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), qr.Total)
+}
+
+func TestStorage_LogRecords(t *testing.T) {
+	ctx := context.Background()
+	s, err := getStorage(ctx)
+	assert.Nil(t, err)
+
+	// the records number provided is ignored
+	log, err := s.CreateLog(ctx, &solaris.Log{Tags: map[string]string{"a": "b"}, Records: 100})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), log.Records)
+	assert.Nil(t, s.UpsertChunkInfos(ctx, log.ID, []logfs.ChunkInfo{{ID: "c1", RecordsCount: 10, Truncated: 3}, {ID: "c2", RecordsCount: 5}}))
+
+	log, err = s.GetLogByID(ctx, log.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(12), log.Records)
+	log.Records = 100
+	log, err = s.UpdateLog(ctx, log)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(12), log.Records)
+	log, err = s.PatchLogTags(ctx, storage.PatchLogTagsRequest{ID: log.ID, Set: map[string]string{"c": "d"}})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(12), log.Records)
+
+	assert.Nil(t, s.DeleteChunkInfos(ctx, log.ID, []string{"c1"}))
+	for _, qr := range []storage.QueryLogsRequest{{IDs: []string{log.ID}}, {Condition: "tag('a') = 'b'"},
+		{Condition: "records = 5", SortBy: solaris.LogsSortBy_LOGS_SORT_BY_UPDATED_AT}} {
+		res, err := s.QueryLogs(ctx, qr)
+		assert.Nil(t, err)
+		assert.Len(t, res.Logs, 1)
+		assert.Equal(t, int64(5), res.Logs[0].Records)
+	}
+	stored, err := s.GetLogs(ctx, "", 10, false)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), stored[0].Records)
+}
+
 func TestStorage_GetLogByID(t *testing.T) {
 	ctx := context.Background()
 	s, err := getStorage(ctx)
@@ -107,6 +275,361 @@ func TestStorage_QueryLogsByCondition(t *testing.T) {
 	assert.Equal(t, qr.NextPageID, log3.ID)
 }
 
+func TestStorage_TagsIndex(t *testing.T) {
+	ctx := context.Background()
+	s, err := getStorage(ctx)
+	assert.Nil(t, err)
+
+	var logs []*solaris.Log
+	for i := 0; i < 4; i++ {
+		log, err := s.CreateLog(ctx, &solaris.Log{Tags: map[string]string{"n": fmt.Sprintf("%d", i), "odd": fmt.Sprintf("%t", i%2 == 1)}})
+		assert.Nil(t, err)
+		logs = append(logs, log)
+	}
+	slices.SortFunc(logs, func(l1, l2 *solaris.Log) int { return strings.Compare(l1.ID, l2.ID) })
+	queryIDs := func(cond string) []string {
+		qr, err := s.QueryLogs(ctx, storage.QueryLogsRequest{Condition: cond, Limit: 100})
+		assert.Nil(t, err)
+		assert.Equal(t, int64(len(qr.Logs)), qr.Total)
+		var res []string
+		for _, l := range qr.Logs {
+			res = append(res, l.ID)
+		}
+		return res
+	}
+	ids := func(idx ...int) []string {
+		var res []string
+		for _, i := range idx {
+			res = append(res, logs[i].ID)
+		}
+		return res
+	}
+
+	assert.Equal(t, ids(1, 3), queryIDs("tag('odd') = 'true'"))
+	assert.Equal(t, ids(0, 3), queryIDs("tag('odd') = 'false' and tag('n') < '2' or tag('n') IN ['3']"))
+	assert.Equal(t, ids(1), queryIDs(fmt.Sprintf("logID = '%s' and tag('odd') = 'true'", logs[1].ID)))
+
+	// the index follows the tags updates
+	logs[0].Tags = map[string]string{"odd": "true"}
+	_, err = s.UpdateLog(ctx, logs[0])
+	assert.Nil(t, err)
+	_, err = s.PatchLogTags(ctx, storage.PatchLogTagsRequest{ID: logs[1].ID, Set: map[string]string{"odd": "false"}, Remove: []string{"n"}})
+	assert.Nil(t, err)
+	_, err = s.BulkPatchLogTags(ctx, storage.BulkPatchLogTagsRequest{Condition: "tag('n') = '2'", Set: map[string]string{"n": "4"}})
+	assert.Nil(t, err)
+	_, err = s.DeleteLogs(ctx, storage.DeleteLogsRequest{IDs: []string{logs[3].ID}})
+	assert.Nil(t, err)
+
+	assert.Equal(t, ids(0), queryIDs("tag('odd') = 'true'"))
+	assert.Equal(t, ids(1, 2), queryIDs("tag('odd') = 'false'"))
+	assert.Empty(t, queryIDs("tag('n') IN ['0', '1', '2', '3']"))
+	assert.Equal(t, ids(2), queryIDs("tag('n') = '4'"))
+	assert.Equal(t, ids(0, 1), queryIDs("tag('n') = ''"))
+
+	// the index is rebuilt if it is missing
+	tx := mustBeginTx(s.db, true)
+	var keys []string
+	assert.Nil(t, tx.AscendKeys("/tags/*", func(key, _ string) bool {
+		keys = append(keys, key)
+		return true
+	}))
+	assert.Len(t, keys, 4)
+	for _, key := range keys {
+		_, err = tx.Delete(key)
+		assert.Nil(t, err)
+	}
+	_, _ = tx.Delete(tagsIndexKey)
+	mustCommit(tx)
+	assert.Empty(t, queryIDs("tag('odd') = 'false'"))
+	assert.Nil(t, s.buildTagsIndex(ctx))
+	assert.Equal(t, ids(1, 2), queryIDs("tag('odd') = 'false'"))
+	assert.Equal(t, ids(0), queryIDs("tag('odd') = 'true'"))
+}
+
+func TestStorage_GetTagFacets(t *testing.T) {
+	ctx := context.Background()
+	s, err := getStorage(ctx)
+	assert.Nil(t, err)
+	assert.Nil(t, s.buildTagsIndex(ctx))
+
+	for _, tags := range []map[string]string{
+		{"env": "prod", "app": "a/1"},
+		{"env": "prod", "app": "b"},
+		{"env": "dev", "app": "a/1", "empty": ""},
+		{"env": "test"},
+	} {
+		_, err = s.CreateLog(ctx, &solaris.Log{Tags: tags})
+		assert.Nil(t, err)
+	}
+	_, err = s.DeleteLogs(ctx, storage.DeleteLogsRequest{Condition: "tag('env') = 'test'", MarkOnly: true})
+	assert.Nil(t, err)
+
+	for _, cond := range []string{"", "tag('env') != 'test'"} {
+		fr, err := s.GetTagFacets(ctx, storage.TagFacetsRequest{Condition: cond})
+		assert.Nil(t, err)
+		assert.Equal(t, int64(2), fr.Total)
+		assert.Equal(t, []*solaris.TagFacet{{Value: "app", Count: 3}, {Value: "env", Count: 3}}, fr.Facets)
+
+		fr, err = s.GetTagFacets(ctx, storage.TagFacetsRequest{Condition: cond, Tag: "env"})
+		assert.Nil(t, err)
+		assert.Equal(t, int64(2), fr.Total)
+		assert.Equal(t, []*solaris.TagFacet{{Value: "dev", Count: 1}, {Value: "prod", Count: 2}}, fr.Facets)
+
+		fr, err = s.GetTagFacets(ctx, storage.TagFacetsRequest{Condition: cond, Tag: "app", Limit: 1})
+		assert.Nil(t, err)
+		assert.Equal(t, int64(2), fr.Total)
+		assert.Equal(t, []*solaris.TagFacet{{Value: "a/1", Count: 2}}, fr.Facets)
+	}
+
+	fr, err := s.GetTagFacets(ctx, storage.TagFacetsRequest{Condition: "tag('env') = 'prod'", Tag: "app"})
+	assert.Nil(t, err)
+	assert.Equal(t, []*solaris.TagFacet{{Value: "a/1", Count: 1}, {Value: "b", Count: 1}}, fr.Facets)
+	fr, err = s.GetTagFacets(ctx, storage.TagFacetsRequest{Tag: "unknown"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), fr.Total)
+	assert.Empty(t, fr.Facets)
+	_, err = s.GetTagFacets(ctx, storage.TagFacetsRequest{Condition: "tag('env') = "})
+	assert.ErrorIs(t, err, errors.ErrInvalid)
+}
+
+func TestStorage_QueryLogsByNumbers(t *testing.T) {
+	ctx := context.Background()
+	s, err := getStorage(ctx)
+	assert.Nil(t, err)
+
+	var logs []*solaris.Log
+	for i, p := range []string{"2", "10", "abc"} {
+		log, err := s.CreateLog(ctx, &solaris.Log{Tags: map[string]string{"priority": p}})
+		assert.Nil(t, err)
+		assert.Nil(t, s.UpsertChunkInfos(ctx, log.ID, []logfs.ChunkInfo{{ID: "c1", RecordsCount: 10}, {ID: "c2", RecordsCount: 10 * i, Truncated: i}}))
+		logs = append(logs, log)
+	}
+	slices.SortFunc(logs, func(l1, l2 *solaris.Log) int { return strings.Compare(l1.ID, l2.ID) })
+	query := func(cond string) []string {
+		qr, err := s.QueryLogs(ctx, storage.QueryLogsRequest{Condition: cond})
+		assert.Nil(t, err)
+		var res []string
+		for _, l := range qr.Logs {
+			res = append(res, l.ID)
+		}
+		return res
+	}
+
+	assert.Equal(t, []string{logs[1].ID}, query("tag('priority') > 3"))
+	assert.Equal(t, []string{logs[0].ID, logs[1].ID}, query("tag('priority') IN [2, 10]"))
+	assert.Equal(t, []string{logs[1].ID, logs[2].ID}, query("records > 10"))
+	assert.Equal(t, []string{logs[2].ID}, query("records = 28 OR tag('priority') = 'none'"))
+	assert.Equal(t, []string{logs[0].ID}, query("tag('priority') < 5 AND records <= 10"))
+}
+
+func TestStorage_QueryLogsAll(t *testing.T) {
+	ctx := context.Background()
+	s, err := getStorage(ctx)
+	assert.Nil(t, err)
+
+	var logs []*solaris.Log
+	for i := 0; i < 5; i++ {
+		log, err := s.CreateLog(ctx, &solaris.Log{Tags: map[string]string{"n": fmt.Sprintf("%d", i)}})
+		assert.Nil(t, err)
+		assert.Nil(t, s.UpsertChunkInfos(ctx, log.ID, []logfs.ChunkInfo{{ID: "c1", RecordsCount: 1}}))
+		// the queried logs records are counted by the chunks
+		log.Records = 1
+		logs = append(logs, log)
+		time.Sleep(time.Millisecond)
+	}
+	slices.SortFunc(logs, func(l1, l2 *solaris.Log) int { return strings.Compare(l1.ID, l2.ID) })
+	_, err = s.DeleteLogs(ctx, storage.DeleteLogsRequest{IDs: []string{logs[4].ID}, MarkOnly: true})
+	assert.Nil(t, err)
+
+	// the logs are paged in the IDs order, the chunks and the deleted logs are skipped
+	qr, err := s.QueryLogs(ctx, storage.QueryLogsRequest{Limit: 3})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(4), qr.Total)
+	assert.Equal(t, logs[:3], qr.Logs)
+	assert.Equal(t, logs[3].ID, qr.NextPageID)
+	qr, err = s.QueryLogs(ctx, storage.QueryLogsRequest{Limit: 3, Page: qr.NextPageID})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(4), qr.Total)
+	assert.Equal(t, logs[3:4], qr.Logs)
+	assert.Empty(t, qr.NextPageID)
+
+	// the most recently updated logs go first
+	_, err = s.PatchLogTags(ctx, storage.PatchLogTagsRequest{ID: logs[1].ID, Set: map[string]string{"upd": "1"}})
+	assert.Nil(t, err)
+	var res []string
+	qr = &solaris.QueryLogsResult{}
+	for {
+		qr, err = s.QueryLogs(ctx, storage.QueryLogsRequest{Limit: 2, Page: qr.NextPageID,
+			SortBy: solaris.LogsSortBy_LOGS_SORT_BY_UPDATED_AT, Descending: true})
+		assert.Nil(t, err)
+		assert.Equal(t, int64(4), qr.Total)
+		for _, l := range qr.Logs {
+			res = append(res, l.ID)
+		}
+		if qr.NextPageID == "" {
+			break
+		}
+	}
+	assert.Equal(t, []string{logs[1].ID, logs[3].ID, logs[2].ID, logs[0].ID}, res)
+
+	qr, err = s.QueryLogs(ctx, storage.QueryLogsRequest{Condition: "tag('n') > '0'", SortBy: solaris.LogsSortBy_LOGS_SORT_BY_CREATED_AT})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), qr.Total)
+	assert.Len(t, qr.Logs, 3)
+	for i, l := range qr.Logs {
+		assert.Equal(t, logs[i+1].ID, l.ID)
+	}
+}
+
+func TestStorage_SortIndex(t *testing.T) {
+	ctx := context.Background()
+	s, err := getStorage(ctx)
+	assert.Nil(t, err)
+
+	var logs []*solaris.Log
+	for i := 0; i < 6; i++ {
+		log, err := s.CreateLog(ctx, &solaris.Log{Tags: map[string]string{"n": fmt.Sprintf("%d", i), "odd": fmt.Sprintf("%t", i%2 == 1)}})
+		assert.Nil(t, err)
+		logs = append(logs, log)
+		time.Sleep(time.Millisecond)
+	}
+	slices.SortFunc(logs, func(l1, l2 *solaris.Log) int { return strings.Compare(l1.ID, l2.ID) })
+	queryIDs := func(qr storage.QueryLogsRequest) []string {
+		var res []string
+		qr.Limit = 2
+		qr.SkipTotal = true
+		for {
+			page, err := s.QueryLogs(ctx, qr)
+			assert.Nil(t, err)
+			assert.Equal(t, int64(0), page.Total)
+			assert.True(t, len(page.Logs) <= 2)
+			for _, l := range page.Logs {
+				res = append(res, l.ID)
+			}
+			if page.NextPageID == "" {
+				return res
+			}
+			qr.Page = page.NextPageID
+		}
+	}
+	ids := func(idx ...int) []string {
+		var res []string
+		for _, i := range idx {
+			res = append(res, logs[i].ID)
+		}
+		return res
+	}
+	byUpdated := storage.QueryLogsRequest{SortBy: solaris.LogsSortBy_LOGS_SORT_BY_UPDATED_AT, Descending: true}
+
+	_, err = s.PatchLogTags(ctx, storage.PatchLogTagsRequest{ID: logs[2].ID, Set: map[string]string{"upd": "1"}})
+	assert.Nil(t, err)
+	_, err = s.DeleteLogs(ctx, storage.DeleteLogsRequest{IDs: []string{logs[3].ID}, MarkOnly: true})
+	assert.Nil(t, err)
+	_, err = s.DeleteLogs(ctx, storage.DeleteLogsRequest{IDs: []string{logs[4].ID}})
+	assert.Nil(t, err)
+	assert.Equal(t, ids(2, 5, 1, 0), queryIDs(byUpdated))
+	assert.Equal(t, ids(0, 1, 2, 5), queryIDs(storage.QueryLogsRequest{SortBy: solaris.LogsSortBy_LOGS_SORT_BY_CREATED_AT}))
+	assert.Equal(t, ids(5, 2, 1, 0), queryIDs(storage.QueryLogsRequest{Descending: true}))
+	assert.Equal(t, ids(5, 2, 1, 0), queryIDs(storage.QueryLogsRequest{Condition: "tag('n') >= '0'", Descending: true}))
+	assert.Equal(t, ids(5, 1), queryIDs(storage.QueryLogsRequest{Condition: "tag('odd') = 'true'", Descending: true}))
+	byUpdated.Condition = "tag('odd') = 'false'"
+	assert.Equal(t, ids(2, 0), queryIDs(byUpdated))
+	byUpdated.Condition = "tag('n') < '3'"
+	assert.Equal(t, ids(2, 1, 0), queryIDs(byUpdated))
+	byUpdated.Deleted = true
+	byUpdated.Condition = ""
+	assert.Equal(t, ids(3, 2, 5, 1, 0), queryIDs(byUpdated))
+
+	_, err = s.UndeleteLogs(ctx, storage.UndeleteLogsRequest{IDs: []string{logs[3].ID}})
+	assert.Nil(t, err)
+	byUpdated.Deleted = false
+	assert.Equal(t, ids(3, 2, 5, 1, 0), queryIDs(byUpdated))
+
+	// the index is built for the existing logs
+	tx := mustBeginTx(s.db, true)
+	var keys []string
+	assert.Nil(t, tx.AscendKeys("/sort/*", func(key, _ string) bool {
+		keys = append(keys, key)
+		return true
+	}))
+	assert.Len(t, keys, 2*5)
+	for _, key := range keys {
+		_, err = tx.Delete(key)
+		assert.Nil(t, err)
+	}
+	_, _ = tx.Delete(sortIndexKey)
+	mustCommit(tx)
+	assert.Empty(t, queryIDs(byUpdated))
+	assert.Nil(t, s.buildSortIndex(ctx))
+	assert.Equal(t, ids(3, 2, 5, 1, 0), queryIDs(byUpdated))
+}
+
+func TestStorage_BuildIndexesInBatches(t *testing.T) {
+	ctx := context.Background()
+	s, err := getStorage(ctx)
+	assert.Nil(t, err)
+
+	n := 2*indexBuildBatchSize + 1
+	var logs []*solaris.Log
+	for i := 0; i < n; i++ {
+		log, err := s.CreateLog(ctx, &solaris.Log{Tags: map[string]string{"all": "1", "small": fmt.Sprintf("%t", i%100 == 0)}})
+		assert.Nil(t, err)
+		logs = append(logs, log)
+	}
+	slices.SortFunc(logs, func(l1, l2 *solaris.Log) int {
+		return strings.Compare(logSortKey(l1, solaris.LogsSortBy_LOGS_SORT_BY_CREATED_AT), logSortKey(l2, solaris.LogsSortBy_LOGS_SORT_BY_CREATED_AT))
+	})
+
+	indexKeys := func() []string {
+		tx := mustBeginTx(s.db, false)
+		defer mustRollback(tx)
+		var keys []string
+		assert.Nil(t, tx.AscendKeys("/tags/*", func(key, _ string) bool {
+			keys = append(keys, key)
+			return true
+		}))
+		assert.Nil(t, tx.AscendKeys("/sort/*", func(key, _ string) bool {
+			keys = append(keys, key)
+			return true
+		}))
+		return keys
+	}
+	keys := indexKeys()
+	assert.Len(t, keys, 4*n)
+
+	tx := mustBeginTx(s.db, true)
+	for _, key := range append(keys, tagsIndexKey, sortIndexKey) {
+		_, _ = tx.Delete(key)
+	}
+	mustCommit(tx)
+	assert.Empty(t, indexKeys())
+	assert.Nil(t, s.buildTagsIndex(ctx))
+	assert.Nil(t, s.buildSortIndex(ctx))
+	assert.Equal(t, keys, indexKeys())
+
+	// the sorted page is selected from the candidates, the many candidates are selected by the sort index
+	for _, cond := range []string{"tag('small') = 'true'", "tag('all') = '1'"} {
+		qr, err := s.QueryLogs(ctx, storage.QueryLogsRequest{Condition: cond, Limit: 2, SkipTotal: true,
+			SortBy: solaris.LogsSortBy_LOGS_SORT_BY_CREATED_AT, Descending: true})
+		assert.Nil(t, err)
+		assert.Len(t, qr.Logs, 2)
+		qr, err = s.QueryLogs(ctx, storage.QueryLogsRequest{Condition: cond, Limit: 2, SkipTotal: true,
+			SortBy: solaris.LogsSortBy_LOGS_SORT_BY_CREATED_AT, Descending: true, Page: qr.NextPageID})
+		assert.Nil(t, err)
+		assert.Len(t, qr.Logs, 2)
+	}
+	qr, err := s.QueryLogs(ctx, storage.QueryLogsRequest{Condition: "tag('small') = 'true'", Limit: 2,
+		SortBy: solaris.LogsSortBy_LOGS_SORT_BY_CREATED_AT, Descending: true})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(21), qr.Total)
+	assert.Equal(t, logs[2000].ID, qr.Logs[0].ID)
+	assert.Equal(t, logs[1900].ID, qr.Logs[1].ID)
+	qr, err = s.QueryLogs(ctx, storage.QueryLogsRequest{Condition: "tag('all') = '1'", Limit: 2, SkipTotal: true,
+		SortBy: solaris.LogsSortBy_LOGS_SORT_BY_CREATED_AT, Page: logSortKey(logs[1000], solaris.LogsSortBy_LOGS_SORT_BY_CREATED_AT)})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{logs[1000].ID, logs[1001].ID}, []string{qr.Logs[0].ID, qr.Logs[1].ID})
+}
+
 func TestStorage_QueryLogsByIDs(t *testing.T) {
 	ctx := context.Background()
 	s, err := getStorage(ctx)
@@ -441,3 +964,176 @@ func getStorage(ctx context.Context) (*Storage, error) {
 	}
 	return s, nil
 }
+
+func TestStorage_UpsertLogsChunkInfos(t *testing.T) {
+	ctx := context.Background()
+	s, err := getStorage(ctx)
+	assert.Nil(t, err)
+
+	log1, err := s.CreateLog(ctx, &solaris.Log{})
+	assert.Nil(t, err)
+	log2, err := s.CreateLog(ctx, &solaris.Log{})
+	assert.Nil(t, err)
+
+	err = s.UpsertLogsChunkInfos(ctx, map[string][]logfs.ChunkInfo{
+		log1.ID: {{ID: "1"}, {ID: "2"}},
+		log2.ID: {{ID: "3"}},
+	})
+	assert.Nil(t, err)
+	cis, err := s.GetChunks(ctx, log1.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(cis))
+	cis, err = s.GetChunks(ctx, log2.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(cis))
+
+	// nothing is stored if one of the logs does not exist
+	err = s.UpsertLogsChunkInfos(ctx, map[string][]logfs.ChunkInfo{
+		log1.ID: {{ID: "4"}},
+		log2.ID: {{ID: "5"}},
+		"noID":  {{ID: "6"}},
+	})
+	assert.ErrorIs(t, err, errors.ErrNotExist)
+	cis, err = s.GetChunks(ctx, log1.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(cis))
+	cis, err = s.GetChunks(ctx, log2.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(cis))
+}
+
+func TestStorage_PurgeDeletedLogs(t *testing.T) {
+	ctx := context.Background()
+	s, err := getStorage(ctx)
+	assert.Nil(t, err)
+
+	log1, err := s.CreateLog(ctx, &solaris.Log{})
+	assert.Nil(t, err)
+	log2, err := s.CreateLog(ctx, &solaris.Log{})
+	assert.Nil(t, err)
+	log3, err := s.CreateLog(ctx, &solaris.Log{})
+	assert.Nil(t, err)
+	assert.Nil(t, s.UpsertChunkInfos(ctx, log1.ID, []logfs.ChunkInfo{{ID: "1"}, {ID: "2"}}))
+	assert.Nil(t, s.UpsertChunkInfos(ctx, log2.ID, []logfs.ChunkInfo{{ID: "3"}}))
+
+	_, err = s.DeleteLogs(ctx, storage.DeleteLogsRequest{IDs: []string{log1.ID, log2.ID}, MarkOnly: true})
+	assert.Nil(t, err)
+
+	logs, err := s.GetDeletedLogs(ctx, "", time.Now().Add(-time.Hour), 10)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(logs))
+	logs, err = s.GetDeletedLogs(ctx, "", time.Now().Add(time.Second), 10)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(logs))
+	logs, err = s.GetDeletedLogs(ctx, "", time.Now().Add(time.Second), 1)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(logs))
+	assert.Equal(t, log1.ID, logs[0].ID)
+	logs, err = s.GetDeletedLogs(ctx, log1.ID, time.Now().Add(time.Second), 10)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(logs))
+	assert.Equal(t, log2.ID, logs[0].ID)
+
+	cis, err := s.BeginPurgeLog(ctx, log1.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(cis))
+	_, err = s.BeginPurgeLog(ctx, log3.ID)
+	assert.ErrorIs(t, err, errors.ErrConflict)
+
+	assert.ErrorIs(t, s.PurgeLog(ctx, log3.ID), errors.ErrConflict)
+	assert.Nil(t, s.PurgeLog(ctx, log1.ID))
+	assert.ErrorIs(t, s.PurgeLog(ctx, log1.ID), errors.ErrNotExist)
+	_, err = s.BeginPurgeLog(ctx, log1.ID)
+	assert.ErrorIs(t, err, errors.ErrNotExist)
+	cis, err = s.BeginPurgeLog(ctx, log2.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(cis))
+
+	logs, err = s.GetDeletedLogs(ctx, "", time.Now().Add(time.Second), 10)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(logs))
+	assert.Equal(t, log2.ID, logs[0].ID)
+	_, err = s.GetLogByID(ctx, log3.ID)
+	assert.Nil(t, err)
+}
+
+func TestStorage_UndeleteLogs(t *testing.T) {
+	ctx := context.Background()
+	s, err := getStorage(ctx)
+	assert.Nil(t, err)
+
+	log1, err := s.CreateLog(ctx, &solaris.Log{Tags: map[string]string{"a": "1"}})
+	assert.Nil(t, err)
+	log2, err := s.CreateLog(ctx, &solaris.Log{Tags: map[string]string{"a": "1"}})
+	assert.Nil(t, err)
+	log3, err := s.CreateLog(ctx, &solaris.Log{Tags: map[string]string{"a": "2"}})
+	assert.Nil(t, err)
+	assert.Nil(t, s.UpsertChunkInfos(ctx, log1.ID, []logfs.ChunkInfo{{ID: "1"}}))
+
+	_, err = s.DeleteLogs(ctx, storage.DeleteLogsRequest{Condition: "tag('a') = '1'", MarkOnly: true})
+	assert.Nil(t, err)
+	_, err = s.GetLogByID(ctx, log1.ID)
+	assert.ErrorIs(t, err, errors.ErrNotExist)
+
+	ur, err := s.UndeleteLogs(ctx, storage.UndeleteLogsRequest{IDs: []string{log1.ID, log3.ID, "noID"}})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{log1.ID}, ur.UndeletedIDs)
+	_, err = s.GetLogByID(ctx, log1.ID)
+	assert.Nil(t, err)
+	cis, err := s.GetChunks(ctx, log1.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(cis))
+
+	// the logs being purged cannot be undeleted
+	_, err = s.DeleteLogs(ctx, storage.DeleteLogsRequest{IDs: []string{log1.ID}, MarkOnly: true})
+	assert.Nil(t, err)
+	_, err = s.BeginPurgeLog(ctx, log1.ID)
+	assert.Nil(t, err)
+	ur, err = s.UndeleteLogs(ctx, storage.UndeleteLogsRequest{Condition: "tag('a') = '1'"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{log2.ID}, ur.UndeletedIDs)
+	_, err = s.GetLogByID(ctx, log1.ID)
+	assert.ErrorIs(t, err, errors.ErrNotExist)
+	_, err = s.GetLogByID(ctx, log2.ID)
+	assert.Nil(t, err)
+
+	ur, err = s.UndeleteLogs(ctx, storage.UndeleteLogsRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(ur.UndeletedIDs))
+}
+
+func TestStorage_GetLogsDeleteChunkInfos(t *testing.T) {
+	ctx := context.Background()
+	s, err := getStorage(ctx)
+	assert.Nil(t, err)
+
+	log1, err := s.CreateLog(ctx, &solaris.Log{Retention: &solaris.Retention{MaxRecords: 10}})
+	assert.Nil(t, err)
+	log2, err := s.CreateLog(ctx, &solaris.Log{})
+	assert.Nil(t, err)
+	log3, err := s.CreateLog(ctx, &solaris.Log{})
+	assert.Nil(t, err)
+	assert.Nil(t, s.UpsertChunkInfos(ctx, log1.ID, []logfs.ChunkInfo{{ID: "1"}, {ID: "2"}, {ID: "3"}}))
+	_, err = s.DeleteLogs(ctx, storage.DeleteLogsRequest{IDs: []string{log2.ID}, MarkOnly: true})
+	assert.Nil(t, err)
+
+	logs, err := s.GetLogs(ctx, "", 10, false)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(logs))
+	assert.Equal(t, log1.ID, logs[0].ID)
+	assert.Equal(t, int64(10), logs[0].Retention.MaxRecords)
+	assert.Equal(t, log3.ID, logs[1].ID)
+	logs, err = s.GetLogs(ctx, "", 1, false)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(logs))
+	logs, err = s.GetLogs(ctx, log1.ID, 10, false)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(logs))
+	assert.Equal(t, log3.ID, logs[0].ID)
+
+	assert.Nil(t, s.DeleteChunkInfos(ctx, log1.ID, []string{"1", "3", "4"}))
+	cis, err := s.GetChunks(ctx, log1.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(cis))
+	assert.Equal(t, "2", cis[0].ID)
+}
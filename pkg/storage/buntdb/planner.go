@@ -0,0 +1,178 @@
+// Copyright 2024 The Solaris Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package buntdb
+
+import (
+	"github.com/solarisdb/solaris/pkg/ql"
+	"slices"
+	"strings"
+)
+
+type (
+	// logsPlan describes how the candidate logs for a condition are selected by the tags index.
+	// The plan is either a lookup, or the intersection (and) or the union (or) of the sub-plans.
+	// The candidate logs are a superset of the logs matching the condition, so the condition
+	// must be evaluated for every candidate log.
+	logsPlan struct {
+		lookup *logsLookup
+		and    []*logsPlan
+		or     []*logsPlan
+	}
+
+	// logsLookup selects the logs having the tag with one of the values, or, if the tag is empty,
+	// the logs with the IDs provided in the values
+	logsLookup struct {
+		tag    string
+		values []string
+	}
+)
+
+// buildLogsPlan returns the plan for selecting the logs which may match the expr by the tags index.
+// Only the equality and IN predicates for tags and log IDs are used for the lookups, the other predicates
+// are residual ones. The function returns false if the index cannot be used for the expr, so all the
+// logs must be scanned. It happens if any of the OR branches of the expr has no predicate for the lookup.
+func buildLogsPlan(expr *ql.Expression) (*logsPlan, bool) {
+	if len(expr.Or) == 0 {
+		return nil, false
+	}
+	var res logsPlan
+	for _, or := range expr.Or {
+		p, ok := buildAndPlan(or)
+		if !ok {
+			return nil, false
+		}
+		res.or = append(res.or, p)
+	}
+	if len(res.or) == 1 {
+		return res.or[0], true
+	}
+	return &res, true
+}
+
+func buildAndPlan(or *ql.OrCondition) (*logsPlan, bool) {
+	var res logsPlan
+	for _, xc := range or.And {
+		// the other predicates of the AND group are residual, so they are skipped
+		if p, ok := buildXCondPlan(xc); ok {
+			res.and = append(res.and, p)
+		}
+	}
+	if len(res.and) == 0 {
+		return nil, false
+	}
+	if len(res.and) == 1 {
+		return res.and[0], true
+	}
+	return &res, true
+}
+
+func buildXCondPlan(xc *ql.XCondition) (*logsPlan, bool) {
+	if xc.Not {
+		return nil, false
+	}
+	if xc.Expr != nil {
+		return buildLogsPlan(xc.Expr)
+	}
+	return buildCondPlan(xc.Cond)
+}
+
+func buildCondPlan(cond *ql.Condition) (*logsPlan, bool) {
+	p1, p2 := cond.FirstParam, cond.SecondParam
+	if p2 == nil {
+		return nil, false
+	}
+	var tag string
+	switch {
+	case p1.Function != nil && p1.Function.Name == "tag" && len(p1.Function.Params) == 1 &&
+		p1.Function.Params[0].ID() == ql.StringParamID:
+		tag = p1.Function.Params[0].Name(true)
+		if tag == "" {
+			return nil, false
+		}
+	case p1.Identifier == "logID":
+	default:
+		return nil, false
+	}
+
+	var values []string
+	switch strings.ToUpper(cond.Op) {
+	case "=":
+		if p2.Const == nil || p2.Const.String == nil {
+			return nil, false
+		}
+		values = []string{p2.Const.Value()}
+	case "IN":
+		if p2.ID() != ql.ArrayParamID {
+			return nil, false
+		}
+		for _, c := range p2.Array {
+			// the numbers are compared numerically, so their string values cannot be looked up
+			if c.String == nil {
+				return nil, false
+			}
+			values = append(values, *c.String)
+		}
+	default:
+		return nil, false
+	}
+	// the logs without the tag match the empty value, but they are not indexed
+	if slices.Contains(values, "") {
+		return nil, false
+	}
+	return &logsPlan{lookup: &logsLookup{tag: tag, values: values}}, true
+}
+
+// execute returns the sorted IDs of the candidate logs. The lookupF returns the IDs of the logs for the lookup.
+func (p *logsPlan) execute(lookupF func(l *logsLookup) ([]string, error)) ([]string, error) {
+	if p.lookup != nil {
+		ids, err := lookupF(p.lookup)
+		if err != nil {
+			return nil, err
+		}
+		slices.Sort(ids)
+		return slices.Compact(ids), nil
+	}
+	if len(p.or) > 0 {
+		var res []string
+		for _, sp := range p.or {
+			ids, err := sp.execute(lookupF)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, ids...)
+		}
+		slices.Sort(res)
+		return slices.Compact(res), nil
+	}
+	var res []string
+	for i, sp := range p.and {
+		ids, err := sp.execute(lookupF)
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			res = ids
+			continue
+		}
+		res = slices.DeleteFunc(res, func(id string) bool {
+			_, found := slices.BinarySearch(ids, id)
+			return !found
+		})
+		if len(res) == 0 {
+			break
+		}
+	}
+	return res, nil
+}
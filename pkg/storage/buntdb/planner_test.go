@@ -0,0 +1,76 @@
+// Copyright 2024 The Solaris Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package buntdb
+
+import (
+	"github.com/solarisdb/solaris/pkg/ql"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestBuildLogsPlan(t *testing.T) {
+	index := map[string][]string{
+		"a=1": {"l1", "l2", "l3"},
+		"a=2": {"l4"},
+		"b=1": {"l2", "l4", "l5"},
+	}
+	lookupF := func(l *logsLookup) ([]string, error) {
+		if l.tag == "" {
+			return l.values, nil
+		}
+		var res []string
+		for _, v := range l.values {
+			res = append(res, index[l.tag+"="+v]...)
+		}
+		return res, nil
+	}
+
+	for _, tc := range []struct {
+		cond string
+		ok   bool
+		ids  []string
+	}{
+		{cond: "", ok: false},
+		{cond: "tag('a') != '1'", ok: false},
+		{cond: "tag('a') = ''", ok: false},
+		{cond: "tag('a') like '1%'", ok: false},
+		{cond: "tag('a') matches '^1$'", ok: false},
+		{cond: "not tag('a') = '1'", ok: false},
+		{cond: "tag('a') = '1' or tag('b') != '1'", ok: false},
+		{cond: "tag('a') = tag('b')", ok: false},
+		{cond: "tag('a') = 1", ok: false},
+		{cond: "tag('a') IN ['1', 2]", ok: false},
+		{cond: "tag('a') = '1'", ok: true, ids: []string{"l1", "l2", "l3"}},
+		{cond: "tag('a') = '3'", ok: true, ids: nil},
+		{cond: "tag('a') IN ['2', '1']", ok: true, ids: []string{"l1", "l2", "l3", "l4"}},
+		{cond: "tag('a') = '1' and tag('b') = '1'", ok: true, ids: []string{"l2"}},
+		{cond: "tag('a') = '1' and tag('c') != '1'", ok: true, ids: []string{"l1", "l2", "l3"}},
+		{cond: "tag('a') = '2' or tag('b') = '1'", ok: true, ids: []string{"l2", "l4", "l5"}},
+		{cond: "tag('b') = '1' and (tag('a') = '2' or logID = 'l5')", ok: true, ids: []string{"l4", "l5"}},
+		{cond: "logID in ['l3', 'l7'] and tag('c') like '%'", ok: true, ids: []string{"l3", "l7"}},
+		{cond: "tag('a') = '2' and logID matches '^l'", ok: true, ids: []string{"l4"}},
+	} {
+		expr, err := ql.Parse(tc.cond)
+		assert.Nil(t, err)
+		plan, ok := buildLogsPlan(expr)
+		assert.Equal(t, tc.ok, ok, tc.cond)
+		if !ok {
+			continue
+		}
+		ids, err := plan.execute(lookupF)
+		assert.Nil(t, err)
+		assert.Equal(t, tc.ids, ids, tc.cond)
+	}
+}
@@ -20,15 +20,19 @@ import (
 	"github.com/solarisdb/solaris/golibs/container/lru"
 	"github.com/solarisdb/solaris/pkg/storage"
 	"github.com/solarisdb/solaris/pkg/storage/logfs"
+	"google.golang.org/protobuf/proto"
 	"sort"
+	"time"
 )
 
 type (
-	// LogsChunksMetaStorage combines storage.Logs and
-	// logfs.LogsMetaStorage interfaces
+	// LogsChunksMetaStorage combines storage.Logs, logfs.LogsMetaStorage,
+	// logfs.DeletedLogsStorage and logfs.RetentionStorage interfaces
 	LogsChunksMetaStorage interface {
 		storage.Logs
 		logfs.LogsMetaStorage
+		logfs.DeletedLogsStorage
+		logfs.RetentionStorage
 	}
 
 	// CachedStorage wraps LogsChunksMetaStorage
@@ -81,26 +85,65 @@ func (s *CachedStorage) CreateLog(ctx context.Context, log *solaris.Log) (*solar
 	return s.storage.CreateLog(ctx, log)
 }
 
-// GetLogByID implements storage.Logs
+// GetLogByID implements storage.Logs. The log records number is changed with the log chunks, so it is
+// calculated by the cached chunks, and the copy of the cached log is returned.
 func (s *CachedStorage) GetLogByID(ctx context.Context, id string) (*solaris.Log, error) {
-	return s.logsCache.GetOrCreate(id)
+	log, err := s.logsCache.GetOrCreate(id)
+	if err != nil {
+		return nil, err
+	}
+	cis, err := s.chunksCache.GetOrCreate(id)
+	if err != nil {
+		return nil, err
+	}
+	log = proto.Clone(log).(*solaris.Log)
+	log.Records = logfs.VisibleRecords(cis)
+	return log, nil
 }
 
-// UpdateLog implements storage.Logs
+// UpdateLog implements storage.Logs. The cached log is removed even if the update fails,
+// so the next read returns the current log version.
 func (s *CachedStorage) UpdateLog(ctx context.Context, log *solaris.Log) (*solaris.Log, error) {
 	l, err := s.storage.UpdateLog(ctx, log)
+	s.logsCache.Remove(log.ID)
+	if err != nil {
+		return nil, err
+	}
+	return l, err
+}
+
+// PatchLogTags implements storage.Logs
+func (s *CachedStorage) PatchLogTags(ctx context.Context, request storage.PatchLogTagsRequest) (*solaris.Log, error) {
+	l, err := s.storage.PatchLogTags(ctx, request)
+	s.logsCache.Remove(request.ID)
 	if err != nil {
 		return nil, err
 	}
-	s.logsCache.Remove(log.ID)
 	return l, err
 }
 
+// BulkPatchLogTags implements storage.Logs
+func (s *CachedStorage) BulkPatchLogTags(ctx context.Context, request storage.BulkPatchLogTagsRequest) (*solaris.BulkPatchLogTagsResult, error) {
+	pr, err := s.storage.BulkPatchLogTags(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range pr.PatchedIDs {
+		s.logsCache.Remove(id)
+	}
+	return pr, err
+}
+
 // QueryLogs implements storage.Logs
 func (s *CachedStorage) QueryLogs(ctx context.Context, qr storage.QueryLogsRequest) (*solaris.QueryLogsResult, error) {
 	return s.storage.QueryLogs(ctx, qr)
 }
 
+// GetTagFacets implements storage.Logs
+func (s *CachedStorage) GetTagFacets(ctx context.Context, request storage.TagFacetsRequest) (*solaris.GetTagFacetsResult, error) {
+	return s.storage.GetTagFacets(ctx, request)
+}
+
 // DeleteLogs implements storage.Logs
 func (s *CachedStorage) DeleteLogs(ctx context.Context, request storage.DeleteLogsRequest) (*solaris.DeleteLogsResult, error) {
 	dr, err := s.storage.DeleteLogs(ctx, request)
@@ -114,6 +157,19 @@ func (s *CachedStorage) DeleteLogs(ctx context.Context, request storage.DeleteLo
 	return dr, nil
 }
 
+// UndeleteLogs implements storage.Logs
+func (s *CachedStorage) UndeleteLogs(ctx context.Context, request storage.UndeleteLogsRequest) (*solaris.UndeleteLogsResult, error) {
+	ur, err := s.storage.UndeleteLogs(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ur.UndeletedIDs {
+		s.logsCache.Remove(id)
+		s.chunksCache.Remove(id)
+	}
+	return ur, nil
+}
+
 // GetLastChunk implements logfs.LogsMetaStorage
 func (s *CachedStorage) GetLastChunk(ctx context.Context, logID string) (logfs.ChunkInfo, error) {
 	cis, err := s.chunksCache.GetOrCreate(logID)
@@ -139,3 +195,48 @@ func (s *CachedStorage) UpsertChunkInfos(ctx context.Context, logID string, cis
 	s.chunksCache.Remove(logID)
 	return nil
 }
+
+// UpsertLogsChunkInfos implements logfs.LogsMetaStorage
+func (s *CachedStorage) UpsertLogsChunkInfos(ctx context.Context, lcis map[string][]logfs.ChunkInfo) error {
+	if err := s.storage.UpsertLogsChunkInfos(ctx, lcis); err != nil {
+		return err
+	}
+	for logID := range lcis {
+		s.chunksCache.Remove(logID)
+	}
+	return nil
+}
+
+// GetDeletedLogs implements logfs.DeletedLogsStorage
+func (s *CachedStorage) GetDeletedLogs(ctx context.Context, afterID string, deletedBefore time.Time, limit int) ([]*solaris.Log, error) {
+	return s.storage.GetDeletedLogs(ctx, afterID, deletedBefore, limit)
+}
+
+// BeginPurgeLog implements logfs.DeletedLogsStorage
+func (s *CachedStorage) BeginPurgeLog(ctx context.Context, logID string) ([]logfs.ChunkInfo, error) {
+	return s.storage.BeginPurgeLog(ctx, logID)
+}
+
+// PurgeLog implements logfs.DeletedLogsStorage
+func (s *CachedStorage) PurgeLog(ctx context.Context, logID string) error {
+	if err := s.storage.PurgeLog(ctx, logID); err != nil {
+		return err
+	}
+	s.logsCache.Remove(logID)
+	s.chunksCache.Remove(logID)
+	return nil
+}
+
+// GetLogs implements logfs.RetentionStorage
+func (s *CachedStorage) GetLogs(ctx context.Context, afterID string, limit int, ownRetentionOnly bool) ([]*solaris.Log, error) {
+	return s.storage.GetLogs(ctx, afterID, limit, ownRetentionOnly)
+}
+
+// DeleteChunkInfos implements logfs.LogsMetaStorage
+func (s *CachedStorage) DeleteChunkInfos(ctx context.Context, logID string, chunkIDs []string) error {
+	if err := s.storage.DeleteChunkInfos(ctx, logID, chunkIDs); err != nil {
+		return err
+	}
+	s.chunksCache.Remove(logID)
+	return nil
+}
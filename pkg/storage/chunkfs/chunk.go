@@ -318,6 +318,42 @@ func (c *Chunk) AppendRecords(recs []*solaris.Record) (AppendRecordsResult, erro
 	return AppendRecordsResult{Written: n, StartID: startID, LastID: lastID}, nil
 }
 
+// TruncateRecords removes the records from the chunk, leaving the first total records only. The function
+// allows to roll back the records that were appended, but could not be committed by the caller.
+func (c *Chunk) TruncateRecords(total int) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.mmf == nil {
+		// chunk is closed
+		return fmt.Errorf("the chunk %s is closed: %w ", c.fn, errors.ErrClosed)
+	}
+	if total < 0 || total > c.total {
+		return fmt.Errorf("could not truncate the chunk with %d records to total=%d: %w", c.total, total, errors.ErrInvalid)
+	}
+	if total == c.total {
+		return nil
+	}
+	freeOffset := cHeaderSize
+	if total > 0 {
+		mb, err := c.getMetaBuf(total-1, 1)
+		if err != nil {
+			return err
+		}
+		mr := mb.get(0)
+		freeOffset = int(mr.offset + mr.size)
+	}
+	hdr, err := c.mmf.Buffer(int64(len(hdrVersion)), 4)
+	if err != nil {
+		c.logger.Errorf("could not map records counter buffer with offset %d for size=4: %v", len(hdrVersion), err)
+		return fmt.Errorf("could not map records counter buffer with offset %d for size=4: %w", len(hdrVersion), errors.ErrInternal)
+	}
+	binary.BigEndian.PutUint32(hdr, uint32(total))
+	c.total = total
+	c.freeOffset = freeOffset
+	return nil
+}
+
 // getMetaBuf maps the meta-buffer for the index startIdx with ln number of meta-records
 func (c *Chunk) getMetaBuf(startIdx, ln int) (metaBuf, error) {
 	offs := c.mmf.Size() - int64(startIdx+1)*cMetaRecordSize
@@ -203,6 +203,52 @@ func TestChunk_AppendGrowth2(t *testing.T) {
 	assert.True(t, arr.StartID.Compare(arr.LastID) < 0)
 }
 
+func TestChunk_TruncateRecords(t *testing.T) {
+	dir, err := os.MkdirTemp("", "TestChunk_TruncateRecords")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	cfg := Config{NewSize: files.BlockSize, MaxChunkSize: 10 * files.BlockSize, MaxGrowIncreaseSize: 2 * files.BlockSize}
+
+	fn := filepath.Join(dir, "c1")
+	files.EnsureFileExists(fn)
+	c := NewChunk(fn, "c1", cfg)
+	assert.Nil(t, c.Open(false))
+	recs := generateRecords(5, 10)
+	_, err = c.AppendRecords(recs)
+	assert.Nil(t, err)
+	before := c.freeOffset
+
+	_, err = c.AppendRecords(generateRecords(3, 20))
+	assert.Nil(t, err)
+	assert.NotNil(t, c.TruncateRecords(9))
+	assert.Nil(t, c.TruncateRecords(5))
+	assert.Equal(t, before, c.freeOffset)
+	cr, err := c.OpenChunkReader(false)
+	assert.Nil(t, err)
+	checkRecords(t, cr, recs)
+	cr.Close()
+
+	// the truncated state is persisted
+	recs2 := generateRecords(2, 30)
+	_, err = c.AppendRecords(recs2)
+	assert.Nil(t, err)
+	assert.Nil(t, c.Close())
+	assert.Nil(t, c.Open(false))
+	cr, err = c.OpenChunkReader(false)
+	assert.Nil(t, err)
+	checkRecords(t, cr, append(recs, recs2...))
+	cr.Close()
+
+	assert.Nil(t, c.TruncateRecords(0))
+	assert.Equal(t, cHeaderSize, c.freeOffset)
+	assert.Nil(t, c.Close())
+	assert.Nil(t, c.Open(false))
+	assert.Equal(t, 0, c.total)
+	assert.Nil(t, c.Close())
+	assert.True(t, errors.Is(c.TruncateRecords(0), errors.ErrClosed))
+}
+
 func checkRecords(t *testing.T, it *ChunkReader, recs []*solaris.Record) {
 	for _, rec := range recs {
 		assert.True(t, it.HasNext())
@@ -15,12 +15,14 @@ package chunkfs
 
 import (
 	"context"
+	"fmt"
 	"github.com/solarisdb/solaris/golibs/container/lru"
 	"github.com/solarisdb/solaris/golibs/errors"
 	"github.com/solarisdb/solaris/golibs/files"
 	"github.com/solarisdb/solaris/golibs/logging"
 	"os"
 	"path/filepath"
+	"sync"
 	"sync/atomic"
 )
 
@@ -33,6 +35,10 @@ type Provider struct {
 	ccfg   Config
 	closed atomic.Bool
 	chunks *lru.ReleasableCache[string, *Chunk]
+
+	lock sync.Mutex
+	// deleting contains the IDs of the chunks which files must be deleted as soon as they are released
+	deleting map[string]struct{}
 }
 
 // NewProvider creates the new Provider instance
@@ -41,6 +47,7 @@ func NewProvider(dir string, maxOpenedChunks int, cfg Config) *Provider {
 	p.logger = logging.NewLogger("chunkfs.Provider")
 	p.dir = dir
 	p.ccfg = cfg
+	p.deleting = make(map[string]struct{})
 	var err error
 	p.chunks, err = lru.NewReleasableCache[string, *Chunk](maxOpenedChunks, p.openChunk, p.closeChunk)
 	if err != nil {
@@ -69,7 +76,45 @@ func (p *Provider) Close() error {
 
 // ReleaseChunk must be called as soon as the chunk is not needed anymore
 func (p *Provider) ReleaseChunk(r *lru.Releasable[*Chunk]) {
+	cID := r.Value().id
 	p.chunks.Release(r)
+	p.lock.Lock()
+	_, deleting := p.deleting[cID]
+	p.lock.Unlock()
+	if deleting {
+		if err := p.deleteChunk(cID); err != nil {
+			p.logger.Warnf("could not delete the released chunk cID=%s: %v", cID, err)
+		}
+	}
+}
+
+// DeleteChunk removes the chunk file by its ID from the local FS. If the chunk is opened and cached, it is
+// closed before the file is removed. If the chunk is in use at the moment, the file is removed as soon as
+// the chunk is released. The chunk file which doesn't exist is considered deleted.
+func (p *Provider) DeleteChunk(ctx context.Context, cID string) error {
+	p.lock.Lock()
+	p.deleting[cID] = struct{}{}
+	p.lock.Unlock()
+	return p.deleteChunk(cID)
+}
+
+func (p *Provider) deleteChunk(cID string) error {
+	if err := p.chunks.Remove(cID); err != nil {
+		if errors.Is(err, errors.ErrConflict) {
+			p.logger.Debugf("the chunk cID=%s is in use, it will be deleted when released", cID)
+			return nil
+		}
+		return fmt.Errorf("could not close the chunk cID=%s: %w", cID, err)
+	}
+	p.lock.Lock()
+	delete(p.deleting, cID)
+	p.lock.Unlock()
+	fn := p.getFileNameByID(cID)
+	p.logger.Debugf("deleting the chunk file %s", fn)
+	if err := os.Remove(fn); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("could not delete the chunk file %s: %w", fn, err)
+	}
+	return nil
 }
 
 func (p *Provider) openChunk(ctx context.Context, cID string) (*Chunk, error) {
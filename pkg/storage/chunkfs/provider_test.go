@@ -104,3 +104,39 @@ func TestProvider_contextClosed(t *testing.T) {
 	p.ReleaseChunk(&c)
 	time.Sleep(time.Millisecond * 100)
 }
+
+func TestProvider_DeleteChunk(t *testing.T) {
+	dir, err := os.MkdirTemp("", "TestProvider_DeleteChunk")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	p := NewProvider(dir, 1, GetDefaultConfig())
+	defer p.Close()
+	assert.Nil(t, p.DeleteChunk(context2.Background(), "lala"))
+
+	rc, err := p.GetOpenedChunk(context2.Background(), "lala", true)
+	assert.Nil(t, err)
+	c := rc.Value()
+	p.ReleaseChunk(&rc)
+	assert.Nil(t, p.DeleteChunk(context2.Background(), "lala"))
+	assert.False(t, c.isOpened())
+	_, err = os.Stat(p.getFileNameByID("lala"))
+	assert.True(t, os.IsNotExist(err))
+	_, err = p.GetOpenedChunk(context2.Background(), "lala", false)
+	assert.NotNil(t, err)
+
+	// the chunk in use is deleted when released
+	rc, err = p.GetOpenedChunk(context2.Background(), "bbbb", true)
+	assert.Nil(t, err)
+	c = rc.Value()
+	assert.Nil(t, p.DeleteChunk(context2.Background(), "bbbb"))
+	_, err = os.Stat(p.getFileNameByID("bbbb"))
+	assert.Nil(t, err)
+	assert.True(t, c.isOpened())
+
+	p.ReleaseChunk(&rc)
+	assert.False(t, c.isOpened())
+	_, err = os.Stat(p.getFileNameByID("bbbb"))
+	assert.True(t, os.IsNotExist(err))
+	assert.Equal(t, 0, len(p.deleting))
+}
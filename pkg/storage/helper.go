@@ -47,7 +47,20 @@ func (l *LogHelper) AppendRecords(ctx context.Context, request *solaris.AppendRe
 		recs[i] = r
 	}
 	l.m[request.LogID] = recs
-	return &solaris.AppendRecordsResult{Added: int64(len(request.Records))}, nil
+	if len(request.Records) == 0 {
+		return &solaris.AppendRecordsResult{}, nil
+	}
+	first, last := request.Records[0], request.Records[len(request.Records)-1]
+	return &solaris.AppendRecordsResult{Added: int64(len(request.Records)), FirstID: first.ID, LastID: last.ID,
+		FirstCreatedAt: first.CreatedAt, LastCreatedAt: last.CreatedAt}, nil
+}
+
+func (l *LogHelper) AppendRecordsBatch(ctx context.Context, requests []*solaris.AppendRecordsRequest) ([]*solaris.AppendRecordsResult, error) {
+	res := make([]*solaris.AppendRecordsResult, len(requests))
+	for i, r := range requests {
+		res[i], _ = l.AppendRecords(ctx, r)
+	}
+	return res, nil
 }
 
 func (l *LogHelper) QueryRecords(ctx context.Context, request QueryRecordsRequest) ([]*solaris.Record, bool, error) {
@@ -80,3 +93,39 @@ func (l *LogHelper) QueryRecords(ctx context.Context, request QueryRecordsReques
 	}
 	return res, idx >= 0 && idx < len(recs), nil
 }
+
+func (l *LogHelper) CountRecords(ctx context.Context, request QueryRecordsRequest) (int64, error) {
+	var res int64
+	for _, r := range l.m[request.LogID] {
+		if request.StartID == "" || request.Descending && r.ID <= request.StartID || !request.Descending && r.ID >= request.StartID {
+			res++
+		}
+	}
+	return res, nil
+}
+
+func (l *LogHelper) TruncateLog(ctx context.Context, request *solaris.TruncateLogRequest) (*solaris.TruncateLogResult, error) {
+	recs := l.m[request.LogID]
+	idx := 0
+	for idx < len(recs) && (request.BeforeRecordID != "" && recs[idx].ID < request.BeforeRecordID ||
+		request.BeforeRecordID == "" && recs[idx].CreatedAt.AsTime().Before(request.BeforeTime.AsTime())) {
+		idx++
+	}
+	l.m[request.LogID] = recs[idx:]
+	return &solaris.TruncateLogResult{Removed: int64(idx)}, nil
+}
+
+func (l *LogHelper) GetLogStats(ctx context.Context, logID string) (*solaris.LogStats, error) {
+	recs := l.m[logID]
+	res := &solaris.LogStats{LogID: logID, Records: int64(len(recs))}
+	for _, r := range recs {
+		res.Size += int64(len(r.Payload))
+	}
+	if len(recs) > 0 {
+		res.Chunks = 1
+		res.FirstRecordID = recs[0].ID
+		res.LastRecordID = recs[len(recs)-1].ID
+		res.LastAppendedAt = recs[len(recs)-1].CreatedAt
+	}
+	return res, nil
+}
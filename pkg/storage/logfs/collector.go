@@ -0,0 +1,232 @@
+// Copyright 2024 The Solaris Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logfs
+
+import (
+	"context"
+	"fmt"
+	"github.com/solarisdb/solaris/api/gen/solaris/v1"
+	"github.com/solarisdb/solaris/golibs/logging"
+	"github.com/solarisdb/solaris/pkg/storage/chunkfs"
+	"sync"
+	"time"
+)
+
+type (
+	// DeletedLogsStorage interface describes the meta storage operations for purging the logs marked deleted
+	DeletedLogsStorage interface {
+		// GetDeletedLogs returns up to limit logs with the IDs greater than afterID in the ascending order
+		// of the IDs, which were marked deleted before the deletedBefore time
+		GetDeletedLogs(ctx context.Context, afterID string, deletedBefore time.Time, limit int) ([]*solaris.Log, error)
+		// BeginPurgeLog marks the log, marked deleted, as being purged, so it cannot be undeleted anymore,
+		// and returns the list of the log chunks. The function returns errors.ErrConflict if the log
+		// is not marked deleted.
+		BeginPurgeLog(ctx context.Context, logID string) ([]ChunkInfo, error)
+		// PurgeLog removes the log marked deleted and its chunks info from the meta storage permanently.
+		// The function returns errors.ErrConflict if the log is not marked deleted.
+		PurgeLog(ctx context.Context, logID string) error
+	}
+
+	// LogLocker interface allows to serialize an operation over a log with the log writes
+	LogLocker interface {
+		// WithLogLock runs f holding the log lock, so no records are written to the log while f runs
+		WithLogLock(ctx context.Context, logID string, f func() error) error
+	}
+
+	// Collector is the garbage collector, which purges the logs marked deleted. The collector runs in
+	// background periodically, and it removes the chunk files of the logs, marked deleted longer than
+	// the grace period ago, and then the logs meta-information. A log is purged under the log lock, so
+	// the purge is serialized with the appends to the log.
+	Collector struct {
+		DLStorage    DeletedLogsStorage `inject:""`
+		ChnkProvider *chunkfs.Provider  `inject:""`
+		LogLocker    LogLocker          `inject:""`
+
+		cfg    CollectorConfig
+		logger logging.Logger
+		cancel context.CancelFunc
+		wg     sync.WaitGroup
+
+		lock  sync.Mutex
+		stats CollectorStats
+	}
+
+	// CollectorConfig defines the Collector settings
+	CollectorConfig struct {
+		// Interval defines how often the collector runs. The value 0 disables the background collection.
+		Interval time.Duration
+		// GracePeriod defines how long the logs marked deleted are kept before being purged
+		GracePeriod time.Duration
+		// BatchSize defines how many deleted logs are selected for purging at a time
+		BatchSize int
+	}
+
+	// CollectorStats contains the collector progress counters
+	CollectorStats struct {
+		// Runs is the number of the collection rounds done
+		Runs int64
+		// PurgedLogs is the number of logs purged
+		PurgedLogs int64
+		// DeletedChunks is the number of chunk files deleted
+		DeletedChunks int64
+		// Errors is the number of errors happened
+		Errors int64
+		// LastRunAt is the time when the last collection round was finished
+		LastRunAt time.Time
+		// LastError contains the last error happened, if any
+		LastError string
+	}
+)
+
+// GetDefaultCollectorConfig returns the default Collector config
+func GetDefaultCollectorConfig() CollectorConfig {
+	return CollectorConfig{
+		Interval:    time.Minute,
+		GracePeriod: 24 * time.Hour,
+		BatchSize:   100,
+	}
+}
+
+// NewCollector creates the new Collector object for the cfg provided
+func NewCollector(cfg CollectorConfig) *Collector {
+	c := new(Collector)
+	c.cfg = cfg
+	if c.cfg.BatchSize <= 0 {
+		c.cfg.BatchSize = GetDefaultCollectorConfig().BatchSize
+	}
+	c.logger = logging.NewLogger("logfs.Collector")
+	return c
+}
+
+// Init implements linker.Initializer
+func (c *Collector) Init(ctx context.Context) error {
+	if c.cfg.Interval <= 0 {
+		c.logger.Infof("Background collection is disabled")
+		return nil
+	}
+	c.logger.Infof("Starting background collection with interval=%s, gracePeriod=%s", c.cfg.Interval, c.cfg.GracePeriod)
+	var cctx context.Context
+	cctx, c.cancel = context.WithCancel(context.Background())
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		ticker := time.NewTicker(c.cfg.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-cctx.Done():
+				return
+			case <-ticker.C:
+				_, _ = c.Collect(cctx)
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown implements linker.Shutdowner
+func (c *Collector) Shutdown() {
+	c.logger.Infof("Shutting down.")
+	if c.cancel != nil {
+		c.cancel()
+	}
+	c.wg.Wait()
+}
+
+// Collect runs one collection round. It purges the logs marked deleted longer than the grace period ago,
+// and returns the number of logs purged. The logs which could not be purged due to an error are left as is,
+// so they will be tried again in the next round. The logs are selected in the order of their IDs, so the
+// failed logs are skipped, and the other logs are purged in the same round.
+func (c *Collector) Collect(ctx context.Context) (int, error) {
+	deletedBefore := time.Now().Add(-c.cfg.GracePeriod)
+	purged := 0
+	afterID := ""
+	var resErr error
+	for ctx.Err() == nil {
+		logs, err := c.DLStorage.GetDeletedLogs(ctx, afterID, deletedBefore, c.cfg.BatchSize)
+		if err != nil {
+			c.onError(fmt.Errorf("could not get deleted logs: %w", err))
+			resErr = err
+			break
+		}
+		n := 0
+		for _, log := range logs {
+			if err = c.purgeLog(ctx, log.ID); err != nil {
+				c.onError(err)
+				resErr = err
+				continue
+			}
+			n++
+		}
+		purged += n
+		if len(logs) < c.cfg.BatchSize {
+			break
+		}
+		afterID = logs[len(logs)-1].ID
+	}
+
+	c.lock.Lock()
+	c.stats.Runs++
+	c.stats.LastRunAt = time.Now()
+	c.lock.Unlock()
+	if purged > 0 {
+		c.logger.Infof("%d deleted logs purged", purged)
+	}
+	return purged, resErr
+}
+
+// Stats returns the collector progress counters
+func (c *Collector) Stats() CollectorStats {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.stats
+}
+
+func (c *Collector) purgeLog(ctx context.Context, logID string) error {
+	return c.LogLocker.WithLogLock(ctx, logID, func() error {
+		return c.purgeLogLocked(ctx, logID)
+	})
+}
+
+func (c *Collector) purgeLogLocked(ctx context.Context, logID string) error {
+	cis, err := c.DLStorage.BeginPurgeLog(ctx, logID)
+	if err != nil {
+		return fmt.Errorf("could not begin purging the log ID=%s: %w", logID, err)
+	}
+	for _, ci := range cis {
+		if err = c.ChnkProvider.DeleteChunk(ctx, ci.ID); err != nil {
+			return fmt.Errorf("could not delete chunk ID=%s of the log ID=%s: %w", ci.ID, logID, err)
+		}
+		c.lock.Lock()
+		c.stats.DeletedChunks++
+		c.lock.Unlock()
+	}
+	if err = c.DLStorage.PurgeLog(ctx, logID); err != nil {
+		return fmt.Errorf("could not purge the log ID=%s: %w", logID, err)
+	}
+	c.lock.Lock()
+	c.stats.PurgedLogs++
+	c.lock.Unlock()
+	c.logger.Debugf("the log ID=%s is purged, %d chunks deleted", logID, len(cis))
+	return nil
+}
+
+func (c *Collector) onError(err error) {
+	c.logger.Warnf("collection error: %v", err)
+	c.lock.Lock()
+	c.stats.Errors++
+	c.stats.LastError = err.Error()
+	c.lock.Unlock()
+}
@@ -0,0 +1,229 @@
+// Copyright 2024 The Solaris Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logfs
+
+import (
+	"context"
+	"fmt"
+	"github.com/solarisdb/solaris/api/gen/solaris/v1"
+	"github.com/solarisdb/solaris/golibs/errors"
+	"github.com/solarisdb/solaris/pkg/storage/chunkfs"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCollector_Collect(t *testing.T) {
+	dir, err := os.MkdirTemp("", "TestCollector_Collect")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	p := chunkfs.NewProvider(dir, 2, chunkfs.GetDefaultConfig())
+	defer p.Close()
+
+	lms := newTestLogsMetaStorage()
+	ll := NewLocalLog(GetDefaultConfig())
+	ll.LMStorage = lms
+	ll.ChnkProvider = p
+	defer ll.Shutdown()
+
+	ctx := context.Background()
+	for _, lid := range []string{"l1", "l2", "l3"} {
+		_, err = ll.AppendRecords(ctx, &solaris.AppendRecordsRequest{Records: generateRecords(10, 100), LogID: lid})
+		assert.Nil(t, err)
+	}
+	l1Chunks, _ := lms.GetChunks(ctx, "l1")
+	l2Chunks, _ := lms.GetChunks(ctx, "l2")
+
+	now := time.Now()
+	lms.markDeleted("l1", now.Add(-2*time.Hour))
+	lms.markDeleted("l2", now.Add(-2*time.Hour))
+	lms.markDeleted("l3", now)
+
+	c := NewCollector(CollectorConfig{GracePeriod: time.Hour, BatchSize: 1})
+	c.DLStorage = lms
+	c.ChnkProvider = p
+	c.LogLocker = ll
+
+	// the l2 chunk is in use, so its file is deleted when the chunk is released
+	rc, err := p.GetOpenedChunk(ctx, l2Chunks[0].ID, false)
+	assert.Nil(t, err)
+	n, err := c.Collect(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, n)
+
+	_, err = lms.GetChunks(ctx, "l1")
+	assert.True(t, errors.Is(err, errors.ErrNotExist))
+	_, err = p.GetOpenedChunk(ctx, l1Chunks[0].ID, false)
+	assert.NotNil(t, err)
+	_, err = lms.GetChunks(ctx, "l2")
+	assert.True(t, errors.Is(err, errors.ErrNotExist))
+	_, err = lms.GetChunks(ctx, "l3")
+	assert.Nil(t, err)
+	l2File := filepath.Join(dir, l2Chunks[0].ID[len(l2Chunks[0].ID)-2:], l2Chunks[0].ID)
+	_, err = os.Stat(l2File)
+	assert.Nil(t, err)
+
+	stats := c.Stats()
+	assert.Equal(t, int64(1), stats.Runs)
+	assert.Equal(t, int64(2), stats.PurgedLogs)
+	assert.Equal(t, int64(len(l1Chunks)+len(l2Chunks)), stats.DeletedChunks)
+	assert.Equal(t, int64(0), stats.Errors)
+
+	p.ReleaseChunk(&rc)
+	_, err = os.Stat(l2File)
+	assert.True(t, os.IsNotExist(err))
+
+	n, err = c.Collect(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, n)
+	_, err = lms.GetChunks(ctx, "l3")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), c.Stats().Runs)
+}
+
+// testFailingLocker fails to lock the log with the failID
+type testFailingLocker struct {
+	LogLocker
+	failID string
+}
+
+func (fl *testFailingLocker) WithLogLock(ctx context.Context, logID string, f func() error) error {
+	if logID == fl.failID {
+		return fmt.Errorf("could not lock the log ID=%s: %w", logID, errors.ErrInternal)
+	}
+	return fl.LogLocker.WithLogLock(ctx, logID, f)
+}
+
+func TestCollector_CollectFailed(t *testing.T) {
+	dir, err := os.MkdirTemp("", "TestCollector_CollectFailed")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	p := chunkfs.NewProvider(dir, 1, chunkfs.GetDefaultConfig())
+	defer p.Close()
+
+	lms := newTestLogsMetaStorage()
+	ll := NewLocalLog(GetDefaultConfig())
+	ll.LMStorage = lms
+	ll.ChnkProvider = p
+	defer ll.Shutdown()
+
+	ctx := context.Background()
+	for _, lid := range []string{"l1", "l2", "l3"} {
+		_, err = ll.AppendRecords(ctx, &solaris.AppendRecordsRequest{Records: generateRecords(10, 100), LogID: lid})
+		assert.Nil(t, err)
+		lms.markDeleted(lid, time.Now().Add(-2*time.Hour))
+	}
+
+	// the first batch purge fails, but the next batches are purged
+	c := NewCollector(CollectorConfig{GracePeriod: time.Hour, BatchSize: 1})
+	c.DLStorage = lms
+	c.ChnkProvider = p
+	c.LogLocker = &testFailingLocker{LogLocker: ll, failID: "l1"}
+	n, err := c.Collect(ctx)
+	assert.True(t, errors.Is(err, errors.ErrInternal))
+	assert.Equal(t, 2, n)
+	_, err = lms.GetChunks(ctx, "l1")
+	assert.Nil(t, err)
+	for _, lid := range []string{"l2", "l3"} {
+		_, err = lms.GetChunks(ctx, lid)
+		assert.True(t, errors.Is(err, errors.ErrNotExist))
+	}
+	assert.Equal(t, int64(1), c.Stats().Errors)
+
+	c.LogLocker = ll
+	n, err = c.Collect(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, n)
+}
+
+func TestCollector_Background(t *testing.T) {
+	dir, err := os.MkdirTemp("", "TestCollector_Background")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	p := chunkfs.NewProvider(dir, 1, chunkfs.GetDefaultConfig())
+	defer p.Close()
+
+	lms := newTestLogsMetaStorage()
+	lms.markDeleted("l1", time.Now())
+	ll := NewLocalLog(GetDefaultConfig())
+	ll.LMStorage = lms
+	ll.ChnkProvider = p
+	defer ll.Shutdown()
+
+	c := NewCollector(CollectorConfig{Interval: 10 * time.Millisecond})
+	c.DLStorage = lms
+	c.ChnkProvider = p
+	c.LogLocker = ll
+	assert.Nil(t, c.Init(context.Background()))
+	assert.Eventually(t, func() bool { return c.Stats().PurgedLogs == 1 }, time.Second, 10*time.Millisecond)
+	c.Shutdown()
+}
+
+func TestCollector_LogLock(t *testing.T) {
+	dir, err := os.MkdirTemp("", "TestCollector_LogLock")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	p := chunkfs.NewProvider(dir, 1, chunkfs.GetDefaultConfig())
+	defer p.Close()
+
+	lms := newTestLogsMetaStorage()
+	ll := NewLocalLog(GetDefaultConfig())
+	ll.LMStorage = lms
+	ll.ChnkProvider = p
+	defer ll.Shutdown()
+
+	ctx := context.Background()
+	_, err = ll.AppendRecords(ctx, &solaris.AppendRecordsRequest{Records: generateRecords(10, 100), LogID: "l1"})
+	assert.Nil(t, err)
+	lms.markDeleted("l1", time.Now().Add(-2*time.Hour))
+
+	c := NewCollector(CollectorConfig{GracePeriod: time.Hour})
+	c.DLStorage = lms
+	c.ChnkProvider = p
+	c.LogLocker = ll
+
+	// the log is locked as it is done by the append, so the purge waits for the lock
+	locked := make(chan struct{})
+	unlock := make(chan struct{})
+	go ll.WithLogLock(ctx, "l1", func() error {
+		close(locked)
+		<-unlock
+		return nil
+	})
+	<-locked
+	done := make(chan int)
+	go func() {
+		n, _ := c.Collect(ctx)
+		done <- n
+	}()
+	select {
+	case <-done:
+		assert.Fail(t, "the log is purged while it is locked")
+	case <-time.After(50 * time.Millisecond):
+	}
+	_, err = lms.GetChunks(ctx, "l1")
+	assert.Nil(t, err)
+
+	close(unlock)
+	assert.Equal(t, 1, <-done)
+	_, err = lms.GetChunks(ctx, "l1")
+	assert.True(t, errors.Is(err, errors.ErrNotExist))
+}
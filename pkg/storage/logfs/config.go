@@ -16,13 +16,29 @@ package logfs
 
 import (
 	"github.com/solarisdb/solaris/golibs/files"
+	"time"
 )
 
 type Config struct {
 	MaxRecordsLimit int
 	MaxBunchSize    int
+	// MaxAppendSize defines the maximum cumulative payload size of the records appended by one request.
+	// The value 0 means no limit.
+	MaxAppendSize int
 	// MaxLocks defines how many different logs may be managed at a time
 	MaxLocks int
+	// MaxBatchLogs defines the maximum number of logs in one AppendRecordsBatch request. The batch
+	// holds the locks of all its logs, so the value may not exceed MaxLocks. The value 0 means MaxLocks.
+	MaxBatchLogs int
+	// DedupWindow defines how long the append requests dedup keys are remembered per log.
+	// The keys are kept in memory and are not preserved between the server restarts.
+	// The value 0 disables the deduplication.
+	DedupWindow time.Duration
+	// DedupMaxKeys defines the maximum number of the dedup keys remembered per log, the oldest
+	// keys are forgotten first. Every key takes about 300 bytes of memory plus the key size, so
+	// the keys memory is about DedupMaxKeys * 300 bytes per every log appended within the DedupWindow.
+	// The value 0 means no limit.
+	DedupMaxKeys int
 }
 
 const (
@@ -34,6 +50,10 @@ func GetDefaultConfig() Config {
 	return Config{
 		MaxRecordsLimit: maxRecordsLimit,
 		MaxBunchSize:    maxBunchSize,
+		MaxAppendSize:   maxBunchSize,
 		MaxLocks:        20000,
+		MaxBatchLogs:    100,
+		DedupWindow:     10 * time.Minute,
+		DedupMaxKeys:    1000,
 	}
 }
@@ -0,0 +1,132 @@
+// Copyright 2024 The Solaris Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logfs
+
+import (
+	"github.com/solarisdb/solaris/api/gen/solaris/v1"
+	"sync"
+	"time"
+)
+
+type (
+	// dedupCache keeps the dedup windows of the logs. The windows are kept apart from the log lockers, so they
+	// are not dropped when the lockers are evicted. The windows without keys are removed by the periodic sweep.
+	// The dedupCache is thread-safe, but the check and the put of a log key are supposed to be done under the log lock.
+	dedupCache struct {
+		lock      sync.Mutex
+		window    time.Duration
+		maxKeys   int
+		logs      map[string]*dedupWindow
+		lastSweep time.Time
+	}
+
+	// dedupWindow remembers the results of the append requests by their dedup keys for the window
+	// period of time. The dedupWindow is not thread-safe.
+	dedupWindow struct {
+		window time.Duration
+		// maxKeys is the maximum number of the keys remembered, the oldest keys are dropped first.
+		// The value 0 means no limit.
+		maxKeys int
+		results map[string]dedupEntry
+		// keys contains the keys in the order they were added, which is the expiration order as well
+		keys []string
+	}
+
+	dedupEntry struct {
+		res       *solaris.AppendRecordsResult
+		expiresAt time.Time
+	}
+)
+
+func newDedupCache(window time.Duration, maxKeys int) *dedupCache {
+	return &dedupCache{window: window, maxKeys: maxKeys, logs: make(map[string]*dedupWindow), lastSweep: time.Now()}
+}
+
+// get returns the result remembered for the key of the log lid, if any
+func (dc *dedupCache) get(lid, key string, now time.Time) (*solaris.AppendRecordsResult, bool) {
+	dc.lock.Lock()
+	defer dc.lock.Unlock()
+	dc.sweep(now)
+	d, ok := dc.logs[lid]
+	if !ok {
+		return nil, false
+	}
+	return d.get(key, now)
+}
+
+// put remembers the result res for the key of the log lid
+func (dc *dedupCache) put(lid, key string, res *solaris.AppendRecordsResult, now time.Time) {
+	dc.lock.Lock()
+	defer dc.lock.Unlock()
+	dc.sweep(now)
+	d, ok := dc.logs[lid]
+	if !ok {
+		d = newDedupWindow(dc.window, dc.maxKeys)
+		dc.logs[lid] = d
+	}
+	d.put(key, res, now)
+}
+
+// sweep removes the expired keys of all the logs once per the window period. The function must be called under the lock
+func (dc *dedupCache) sweep(now time.Time) {
+	if now.Sub(dc.lastSweep) < dc.window {
+		return
+	}
+	dc.lastSweep = now
+	for lid, d := range dc.logs {
+		if d.sweep(now); len(d.keys) == 0 {
+			delete(dc.logs, lid)
+		}
+	}
+}
+
+func newDedupWindow(window time.Duration, maxKeys int) *dedupWindow {
+	return &dedupWindow{window: window, maxKeys: maxKeys, results: make(map[string]dedupEntry)}
+}
+
+// get returns the result remembered for the key, if any
+func (d *dedupWindow) get(key string, now time.Time) (*solaris.AppendRecordsResult, bool) {
+	d.sweep(now)
+	e, ok := d.results[key]
+	return e.res, ok
+}
+
+// put remembers the result res for the key
+func (d *dedupWindow) put(key string, res *solaris.AppendRecordsResult, now time.Time) {
+	d.sweep(now)
+	if _, ok := d.results[key]; ok {
+		return
+	}
+	if d.maxKeys > 0 && len(d.keys) >= d.maxKeys {
+		n := len(d.keys) - d.maxKeys + 1
+		for _, k := range d.keys[:n] {
+			delete(d.results, k)
+		}
+		d.keys = append(d.keys[:0], d.keys[n:]...)
+	}
+	d.results[key] = dedupEntry{res: res, expiresAt: now.Add(d.window)}
+	d.keys = append(d.keys, key)
+}
+
+// sweep removes the expired results
+func (d *dedupWindow) sweep(now time.Time) {
+	i := 0
+	for ; i < len(d.keys) && !now.Before(d.results[d.keys[i]].expiresAt); i++ {
+		delete(d.results, d.keys[i])
+	}
+	if i > 0 {
+		d.keys = append(d.keys[:0], d.keys[i:]...)
+	}
+}
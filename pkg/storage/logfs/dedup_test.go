@@ -0,0 +1,83 @@
+// Copyright 2024 The Solaris Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logfs
+
+import (
+	"github.com/solarisdb/solaris/api/gen/solaris/v1"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestDedupWindow(t *testing.T) {
+	now := time.Now()
+	d := newDedupWindow(time.Minute, 0)
+	_, ok := d.get("k1", now)
+	assert.False(t, ok)
+
+	r1 := &solaris.AppendRecordsResult{Added: 1}
+	r2 := &solaris.AppendRecordsResult{Added: 2}
+	d.put("k1", r1, now)
+	d.put("k2", r2, now.Add(30*time.Second))
+	d.put("k1", r2, now.Add(30*time.Second))
+	res, ok := d.get("k1", now.Add(59*time.Second))
+	assert.True(t, ok)
+	assert.Equal(t, r1, res)
+
+	_, ok = d.get("k1", now.Add(time.Minute))
+	assert.False(t, ok)
+	assert.Equal(t, []string{"k2"}, d.keys)
+	res, ok = d.get("k2", now.Add(time.Minute))
+	assert.True(t, ok)
+	assert.Equal(t, r2, res)
+
+	_, ok = d.get("k2", now.Add(2*time.Minute))
+	assert.False(t, ok)
+	assert.Equal(t, 0, len(d.keys))
+	assert.Equal(t, 0, len(d.results))
+}
+
+func TestDedupWindow_MaxKeys(t *testing.T) {
+	now := time.Now()
+	d := newDedupWindow(time.Minute, 2)
+	for _, k := range []string{"k1", "k2", "k3"} {
+		d.put(k, &solaris.AppendRecordsResult{}, now)
+	}
+	_, ok := d.get("k1", now)
+	assert.False(t, ok)
+	_, ok = d.get("k3", now)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"k2", "k3"}, d.keys)
+	assert.Equal(t, 2, len(d.results))
+}
+
+func TestDedupCache(t *testing.T) {
+	now := time.Now()
+	dc := newDedupCache(time.Minute, 10)
+	r1 := &solaris.AppendRecordsResult{Added: 1}
+	dc.put("l1", "k1", r1, now)
+	dc.put("l2", "k1", &solaris.AppendRecordsResult{Added: 2}, now.Add(30*time.Second))
+	res, ok := dc.get("l1", "k1", now.Add(59*time.Second))
+	assert.True(t, ok)
+	assert.Equal(t, r1, res)
+	_, ok = dc.get("l3", "k1", now)
+	assert.False(t, ok)
+
+	// the windows without keys are removed by the sweep
+	assert.Equal(t, 2, len(dc.logs))
+	_, ok = dc.get("l2", "k1", now.Add(2*time.Minute))
+	assert.False(t, ok)
+	assert.Equal(t, 0, len(dc.logs))
+}
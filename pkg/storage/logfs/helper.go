@@ -16,28 +16,106 @@ package logfs
 
 import (
 	"context"
+	"github.com/solarisdb/solaris/api/gen/solaris/v1"
 	"github.com/solarisdb/solaris/golibs/errors"
 	"slices"
 	"sort"
 	"sync"
+	"time"
 )
 
 type testLogsMetaStorage struct {
-	lock sync.Mutex
-	logs map[string][]ChunkInfo
+	lock       sync.Mutex
+	logs       map[string][]ChunkInfo
+	deleted    map[string]time.Time
+	retentions map[string]*solaris.Retention
 }
 
 func newTestLogsMetaStorage() *testLogsMetaStorage {
 	lms := new(testLogsMetaStorage)
 	lms.logs = make(map[string][]ChunkInfo)
+	lms.deleted = make(map[string]time.Time)
+	lms.retentions = make(map[string]*solaris.Retention)
 	return lms
 }
 
+func (lms *testLogsMetaStorage) setRetention(logID string, rp *solaris.Retention) {
+	lms.lock.Lock()
+	defer lms.lock.Unlock()
+	lms.retentions[logID] = rp
+}
+
+func (lms *testLogsMetaStorage) GetLogs(_ context.Context, afterID string, limit int, ownRetentionOnly bool) ([]*solaris.Log, error) {
+	lms.lock.Lock()
+	defer lms.lock.Unlock()
+	var res []*solaris.Log
+	for lid := range lms.logs {
+		rp := lms.retentions[lid]
+		if _, ok := lms.deleted[lid]; !ok && lid > afterID && (!ownRetentionOnly || rp != nil) {
+			res = append(res, &solaris.Log{ID: lid, Retention: rp})
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].ID < res[j].ID
+	})
+	return res[:min(limit, len(res))], nil
+}
+
+func (lms *testLogsMetaStorage) DeleteChunkInfos(_ context.Context, logID string, chunkIDs []string) error {
+	lms.lock.Lock()
+	defer lms.lock.Unlock()
+	lms.logs[logID] = slices.DeleteFunc(slices.Clone(lms.logs[logID]), func(ci ChunkInfo) bool {
+		return slices.Contains(chunkIDs, ci.ID)
+	})
+	return nil
+}
+
+func (lms *testLogsMetaStorage) markDeleted(logID string, at time.Time) {
+	lms.lock.Lock()
+	defer lms.lock.Unlock()
+	lms.deleted[logID] = at
+}
+
+func (lms *testLogsMetaStorage) GetDeletedLogs(_ context.Context, afterID string, deletedBefore time.Time, limit int) ([]*solaris.Log, error) {
+	lms.lock.Lock()
+	defer lms.lock.Unlock()
+	var res []*solaris.Log
+	for lid, at := range lms.deleted {
+		if lid > afterID && at.Before(deletedBefore) {
+			res = append(res, &solaris.Log{ID: lid})
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].ID < res[j].ID
+	})
+	return res[:min(limit, len(res))], nil
+}
+
+func (lms *testLogsMetaStorage) BeginPurgeLog(_ context.Context, logID string) ([]ChunkInfo, error) {
+	lms.lock.Lock()
+	defer lms.lock.Unlock()
+	if _, ok := lms.deleted[logID]; !ok {
+		return nil, errors.ErrConflict
+	}
+	return lms.logs[logID], nil
+}
+
+func (lms *testLogsMetaStorage) PurgeLog(_ context.Context, logID string) error {
+	lms.lock.Lock()
+	defer lms.lock.Unlock()
+	if _, ok := lms.deleted[logID]; !ok {
+		return errors.ErrConflict
+	}
+	delete(lms.deleted, logID)
+	delete(lms.logs, logID)
+	return nil
+}
+
 func (lms *testLogsMetaStorage) GetLastChunk(_ context.Context, logID string) (ChunkInfo, error) {
 	lms.lock.Lock()
 	defer lms.lock.Unlock()
 	cis, ok := lms.logs[logID]
-	if !ok {
+	if !ok || len(cis) == 0 {
 		return ChunkInfo{}, errors.ErrNotExist
 	}
 	return cis[len(cis)-1], nil
@@ -54,11 +132,26 @@ func (lms *testLogsMetaStorage) GetChunks(ctx context.Context, logID string) ([]
 }
 
 func (lms *testLogsMetaStorage) UpsertChunkInfos(ctx context.Context, logID string, cis []ChunkInfo) error {
+	lms.lock.Lock()
+	defer lms.lock.Unlock()
+	return lms.upsertChunkInfos(logID, cis)
+}
+
+func (lms *testLogsMetaStorage) UpsertLogsChunkInfos(ctx context.Context, lcis map[string][]ChunkInfo) error {
+	lms.lock.Lock()
+	defer lms.lock.Unlock()
+	for logID, cis := range lcis {
+		if err := lms.upsertChunkInfos(logID, cis); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (lms *testLogsMetaStorage) upsertChunkInfos(logID string, cis []ChunkInfo) error {
 	if len(cis) == 0 {
 		return nil
 	}
-	lms.lock.Lock()
-	defer lms.lock.Unlock()
 	sort.Slice(cis, func(i, j int) bool {
 		return cis[i].ID < cis[j].ID
 	})
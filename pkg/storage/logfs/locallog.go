@@ -24,11 +24,17 @@ import (
 	"github.com/solarisdb/solaris/golibs/errors"
 	"github.com/solarisdb/solaris/golibs/logging"
 	"github.com/solarisdb/solaris/golibs/ulidutils"
+	"github.com/solarisdb/solaris/pkg/intervals"
+	"github.com/solarisdb/solaris/pkg/ql"
 	"github.com/solarisdb/solaris/pkg/storage"
 	"github.com/solarisdb/solaris/pkg/storage/chunkfs"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"os"
+	"slices"
 	"sort"
 	"sync"
+	"time"
 )
 
 type (
@@ -36,10 +42,16 @@ type (
 	localLog struct {
 		LMStorage    LogsMetaStorage   `inject:""`
 		ChnkProvider *chunkfs.Provider `inject:""`
+		Notifier     *storage.Notifier `inject:""`
 
 		cfg     Config
 		logger  logging.Logger
 		lockers *lru.ReleasableCache[string, *logLocker]
+		// dedup remembers the recent append requests with the dedup keys
+		dedup *dedupCache
+		// batchLock serializes the batches obtaining the log lockers, so the batches cannot exhaust
+		// the lockers capacity by holding a part of the lockers each
+		batchLock sync.Mutex
 	}
 
 	logLocker struct {
@@ -54,18 +66,28 @@ type (
 		GetChunks(ctx context.Context, logID string) ([]ChunkInfo, error)
 		// UpsertChunkInfos update or insert new records associated with logID into the meta-storage
 		UpsertChunkInfos(ctx context.Context, logID string, cis []ChunkInfo) error
+		// UpsertLogsChunkInfos update or insert new records associated with several logs (the map keys)
+		// into the meta-storage atomically, so either all the records are stored, or none of them
+		UpsertLogsChunkInfos(ctx context.Context, lcis map[string][]ChunkInfo) error
+		// DeleteChunkInfos removes the chunks info of the log from the meta-storage
+		DeleteChunkInfos(ctx context.Context, logID string, chunkIDs []string) error
 	}
 
 	// ChunkInfo is the descriptor which describes a chunk information in the log meta-storage
 	ChunkInfo struct {
 		// ID is the chunk ID
 		ID string `json:"id"`
-		// Min is the minimum (first) record ID stored in the chunk
+		// Min is the minimum (first) record ID stored in the chunk. The value is the low-watermark of
+		// the truncated chunk: the records with lesser IDs are truncated and not visible for the readers.
 		Min ulid.ULID `json:"min"`
 		// Max is the maximum (last) record ID stored in the chunk
 		Max ulid.ULID `json:"max"`
-		// RecordsCount is the number of records stored in the chunk
+		// RecordsCount is the number of records stored in the chunk, including the truncated ones
 		RecordsCount int `json:"recordsCount"`
+		// Truncated is the number of records stored in the chunk, but truncated (they are before Min)
+		Truncated int `json:"truncated,omitempty"`
+		// Size is the cumulative payload size of the not truncated records stored in the chunk
+		Size int64 `json:"size,omitempty"`
 	}
 )
 
@@ -77,12 +99,20 @@ const (
 )
 
 var _ storage.Log = (*localLog)(nil)
+var _ LogLocker = (*localLog)(nil)
+
+// newCtimeIntervalBuilder returns the builder which allows to select the time intervals for the records
+// condition, so the chunks which records are out of the intervals are not read at all
+func newCtimeIntervalBuilder(d ql.Dialect[*solaris.Record]) ql.ParamIntervalBuilder[time.Time, *solaris.Record] {
+	return ql.NewParamIntervalBuilder(intervals.BasisTime, d, "ctime", ql.OpsGtLt)
+}
 
 // NewLocalLog creates the new localLog object for the cfg provided
 func NewLocalLog(cfg Config) *localLog {
 	l := new(localLog)
 	l.cfg = cfg
 	l.logger = logging.NewLogger("localLog")
+	l.dedup = newDedupCache(cfg.DedupWindow, cfg.DedupMaxKeys)
 	var err error
 	l.lockers, err = lru.NewReleasableCache[string, *logLocker](cfg.MaxLocks,
 		func(ctx context.Context, lid string) (*logLocker, error) {
@@ -101,9 +131,16 @@ func (l *localLog) Shutdown() {
 }
 
 // AppendRecords allows to write reocrds into the chunks on the local FS and update the Logs catalog with the new
-// chunks created
+// chunks created. The cumulative payload size of the request records may not exceed the MaxAppendSize.
+// If the request has the DedupKey and the request with the same key was applied within the DedupWindow,
+// the records are not written again, but the result of the previous request is returned. If the request
+// has the expected last record ID or records count, which do not match the log, the errors.ErrConflict is returned.
 func (l *localLog) AppendRecords(ctx context.Context, request *solaris.AppendRecordsRequest) (*solaris.AppendRecordsResult, error) {
 	lid := request.LogID
+	if err := l.checkAppendSize(request); err != nil {
+		return nil, err
+	}
+
 	ll, err := l.lockers.GetOrCreate(ctx, lid)
 	if err != nil {
 		return nil, fmt.Errorf("could not obtain the log locker for id=%s: %w", lid, err)
@@ -112,31 +149,237 @@ func (l *localLog) AppendRecords(ctx context.Context, request *solaris.AppendRec
 	ll.Value().lock.Lock()
 	defer ll.Value().lock.Unlock()
 
-	cis := []ChunkInfo{}
+	dk := l.dedupKey(request)
+	if res, ok := l.getDedupResult(lid, dk); ok {
+		return res, nil
+	}
+
+	lw, gerr := l.writeRecords(ctx, request)
+	if lw.added > 0 {
+		// use context.Background instead of ctx to avoid some unrecoverable error in case of the ctx is closed, but we have some
+		// data written
+		if err := l.LMStorage.UpsertChunkInfos(context.Background(), lid, lw.cis); err != nil {
+			// well, now it is unrecoverable!
+			l.logger.Errorf("could not write chunk IDs=%v for logID=%s, but the data is written into chunk. The data is corrupted now: %v", lw.cis, lid, err)
+			panic("unrecoverable error, data is corrupted")
+		}
+		if gerr != nil {
+			l.logger.Warnf("AppendRecords: got the error=%v, but would be able to write some data for logID=%s, added=%d", gerr, lid, lw.added)
+		}
+		gerr = nil // disregard the error, cause we could write something
+		if l.Notifier != nil {
+			l.Notifier.Notify(lid)
+		}
+	}
+
+	if lw.added == 0 {
+		return &solaris.AppendRecordsResult{}, gerr
+	}
+	res := lw.result()
+	if dk != "" {
+		l.dedup.put(lid, dk, proto.Clone(res).(*solaris.AppendRecordsResult), time.Now())
+	}
+	return res, gerr
+}
+
+// AppendRecordsBatch allows to write records into several logs atomically, so either all the requests records
+// are added, or none of them. Every request is handled the same way as by AppendRecords, but any error, including
+// the case when not all the records fit into the chunks, fails the whole batch. Every log may be met in the batch
+// once, and the number of logs may not exceed the MaxBatchLogs. The function returns the results in the order of
+// the requests.
+func (l *localLog) AppendRecordsBatch(ctx context.Context, requests []*solaris.AppendRecordsRequest) ([]*solaris.AppendRecordsResult, error) {
+	if maxLogs := l.maxBatchLogs(); len(requests) > maxLogs {
+		return nil, fmt.Errorf("the batch contains %d logs, but the maximum is %d: %w", len(requests), maxLogs, errors.ErrInvalid)
+	}
+	lids := make([]string, 0, len(requests))
+	for _, r := range requests {
+		if err := l.checkAppendSize(r); err != nil {
+			return nil, err
+		}
+		lids = append(lids, r.LogID)
+	}
+	sort.Strings(lids)
+	for i := 1; i < len(lids); i++ {
+		if lids[i] == lids[i-1] {
+			return nil, fmt.Errorf("the logID=%s is met more than once in the batch: %w", lids[i], errors.ErrInvalid)
+		}
+	}
+
+	// the logs are locked in the order of their IDs to avoid deadlocks with other batches. The lockers are
+	// obtained by one batch at a time, so the batch waiting for the lockers capacity doesn't block the others
+	l.batchLock.Lock()
+	for _, lid := range lids {
+		ll, err := l.lockers.GetOrCreate(ctx, lid)
+		if err != nil {
+			l.batchLock.Unlock()
+			return nil, fmt.Errorf("could not obtain the log locker for id=%s: %w", lid, err)
+		}
+		defer l.lockers.Release(&ll)
+		ll.Value().lock.Lock()
+		defer ll.Value().lock.Unlock()
+	}
+	l.batchLock.Unlock()
+
+	res := make([]*solaris.AppendRecordsResult, len(requests))
+	lws := make([]*logWrite, 0, len(requests))
+	rollback := func(err error) error {
+		for _, lw := range lws {
+			if rerr := l.rollback(lw); rerr != nil {
+				return fmt.Errorf("%w (the rollback failed: %w)", err, rerr)
+			}
+		}
+		return err
+	}
+	for i, r := range requests {
+		if dr, ok := l.getDedupResult(r.LogID, l.dedupKey(r)); ok {
+			res[i] = dr
+			continue
+		}
+		lw, err := l.writeRecords(ctx, r)
+		lws = append(lws, lw)
+		if err == nil && lw.added < len(r.Records) {
+			err = fmt.Errorf("only %d records of %d could be written into the logID=%s: %w", lw.added, len(r.Records), r.LogID, errors.ErrExhausted)
+		}
+		if err != nil {
+			return nil, rollback(err)
+		}
+		res[i] = lw.result()
+	}
+
+	lcis := make(map[string][]ChunkInfo, len(lws))
+	for _, lw := range lws {
+		if lw.added > 0 {
+			lcis[lw.lid] = lw.cis
+		}
+	}
+	if len(lcis) == 0 {
+		return res, nil
+	}
+	if err := l.LMStorage.UpsertLogsChunkInfos(context.Background(), lcis); err != nil {
+		l.logger.Warnf("could not write chunk infos for the logs=%v, rolling back the batch: %v", lids, err)
+		return nil, rollback(err)
+	}
+	for i, r := range requests {
+		if dk := l.dedupKey(r); dk != "" {
+			l.dedup.put(r.LogID, dk, proto.Clone(res[i]).(*solaris.AppendRecordsResult), time.Now())
+		}
+	}
+	if l.Notifier != nil {
+		for lid := range lcis {
+			l.Notifier.Notify(lid)
+		}
+	}
+	return res, nil
+}
+
+// logWrite describes the records written into the log chunks, but not committed into the LogsMetaStorage yet
+type logWrite struct {
+	lid string
+	// last is the last log chunk before the write
+	last ChunkInfo
+	// cis contains the chunk infos to be committed
+	cis             []ChunkInfo
+	added           int
+	firstID, lastID ulid.ULID
+}
+
+func (lw *logWrite) result() *solaris.AppendRecordsResult {
+	if lw.added == 0 {
+		return &solaris.AppendRecordsResult{}
+	}
+	return &solaris.AppendRecordsResult{
+		Added:          int64(lw.added),
+		FirstID:        lw.firstID.String(),
+		LastID:         lw.lastID.String(),
+		FirstCreatedAt: timestamppb.New(ulid.Time(lw.firstID.Time())),
+		LastCreatedAt:  timestamppb.New(ulid.Time(lw.lastID.Time())),
+	}
+}
+
+// maxBatchLogs returns the maximum number of logs in one batch
+func (l *localLog) maxBatchLogs() int {
+	if l.cfg.MaxBatchLogs <= 0 || l.cfg.MaxBatchLogs > l.cfg.MaxLocks {
+		return l.cfg.MaxLocks
+	}
+	return l.cfg.MaxBatchLogs
+}
+
+func (l *localLog) checkAppendSize(request *solaris.AppendRecordsRequest) error {
+	size := 0
+	for _, r := range request.Records {
+		size += len(r.Payload)
+	}
+	if l.cfg.MaxAppendSize > 0 && size > l.cfg.MaxAppendSize {
+		return fmt.Errorf("the records payload size=%d exceeds the maximum=%d: %w", size, l.cfg.MaxAppendSize, errors.ErrExhausted)
+	}
+	return nil
+}
+
+// dedupKey returns the request dedup key, or empty string if the deduplication is disabled
+func (l *localLog) dedupKey(request *solaris.AppendRecordsRequest) string {
+	if l.cfg.DedupWindow <= 0 {
+		return ""
+	}
+	return request.DedupKey
+}
+
+// getDedupResult returns the result of the request with the dedup key dk applied before, if any.
+// The function must be called under the log lock.
+func (l *localLog) getDedupResult(lid, dk string) (*solaris.AppendRecordsResult, bool) {
+	if dk == "" {
+		return nil, false
+	}
+	res, ok := l.dedup.get(lid, dk, time.Now())
+	if !ok {
+		return nil, false
+	}
+	l.logger.Debugf("the request with dedupKey=%s was already applied to the logID=%s", dk, lid)
+	return proto.Clone(res).(*solaris.AppendRecordsResult), true
+}
+
+// writeRecords writes the request records into the log chunks. The written records are not visible for the
+// readers until the returned logWrite chunk infos are committed into the LogsMetaStorage. The function may write
+// some records and return an error, so the result must be either committed or rolled back in any case.
+// The function must be called under the log lock.
+func (l *localLog) writeRecords(ctx context.Context, request *solaris.AppendRecordsRequest) (*logWrite, error) {
+	lid := request.LogID
+	lw := &logWrite{lid: lid}
 
 	ci, err := l.LMStorage.GetLastChunk(ctx, lid)
 	if err != nil && !errors.Is(err, errors.ErrNotExist) {
-		return nil, err
+		return lw, err
+	}
+	if err := l.checkAppendCondition(ctx, request, ci); err != nil {
+		return lw, err
 	}
+	lw.last = ci
 
 	recs := request.Records
-	added := 0
-	var gerr error
 	for len(recs) > 0 {
-		if ci.RecordsCount == 0 {
+		newChunk := ci.RecordsCount == 0
+		if newChunk {
 			ci = ChunkInfo{ID: ulidutils.NewID()}
 			l.logger.Infof("creating new chunk id=%s for the logID=%s", ci.ID, lid)
 		}
-		rc, err := l.ChnkProvider.GetOpenedChunk(ctx, ci.ID, ci.RecordsCount == 0)
+		rc, err := l.ChnkProvider.GetOpenedChunk(ctx, ci.ID, newChunk)
 		if err != nil {
-			gerr = err
-			break
+			if newChunk {
+				l.deleteChunk(lid, ci.ID)
+			}
+			return lw, err
 		}
 		arr, err := rc.Value().AppendRecords(recs)
 		l.ChnkProvider.ReleaseChunk(&rc) // release the chunk ASAP
 		if err != nil {
-			gerr = err
-			break
+			if newChunk {
+				l.deleteChunk(lid, ci.ID)
+			}
+			if newChunk || !errors.Is(err, errors.ErrExhausted) {
+				return lw, err
+			}
+			// the chunk cannot grow to fit the records, so continue with the new one
+			l.logger.Debugf("the chunk id=%s of the logID=%s is full: %v", ci.ID, lid, err)
+			arr = chunkfs.AppendRecordsResult{}
 		}
 		if arr.Written > 0 {
 			if ci.RecordsCount == 0 {
@@ -144,40 +387,106 @@ func (l *localLog) AppendRecords(ctx context.Context, request *solaris.AppendRec
 			}
 			ci.Max = arr.LastID
 			ci.RecordsCount += arr.Written
-			cis = append(cis, ci)
+			for _, r := range recs[:arr.Written] {
+				ci.Size += int64(len(r.Payload))
+			}
+			if lw.added == 0 {
+				lw.firstID = arr.StartID
+			}
+			lw.lastID = arr.LastID
+			lw.cis = append(lw.cis, ci)
 			recs = recs[arr.Written:]
-			added += arr.Written
-		} else if ci.RecordsCount == 0 {
+			lw.added += arr.Written
+		} else if newChunk {
 			// the chunk was just created and its capacity is not enough to write at least one record!
-			gerr = fmt.Errorf("It seems the maximum chunk size is less than the record size payload=%d: %w", len(recs[0].Payload), errors.ErrInvalid)
-			break
+			l.deleteChunk(lid, ci.ID)
+			return lw, fmt.Errorf("It seems the maximum chunk size is less than the record size payload=%d: %w", len(recs[0].Payload), errors.ErrInvalid)
 		}
 		ci.RecordsCount = 0
 	}
+	return lw, nil
+}
 
-	if added > 0 {
-		// use context.Background instead of ctx to avoid some unrecoverable error in case of the ctx is closed, but we have some
-		// data written
-		if err := l.LMStorage.UpsertChunkInfos(context.Background(), lid, cis); err != nil {
-			// well, now it is unrecoverable!
-			l.logger.Errorf("could not write chunk IDs=%v for logID=%s, but the data is written into chunk. The data is corrupted now: %v", cis, lid, err)
-			panic("unrecoverable error, data is corrupted")
+// rollback removes the not committed records of lw. The chunks created by the write are deleted, and the
+// last chunk before the write is truncated to its committed records. The function must be called under the log lock.
+func (l *localLog) rollback(lw *logWrite) error {
+	for _, ci := range lw.cis {
+		if ci.ID != lw.last.ID {
+			// the chunk is created by the write, nothing refers to it
+			if err := l.ChnkProvider.DeleteChunk(context.Background(), ci.ID); err != nil {
+				l.logger.Errorf("could not delete the chunk ID=%s created for logID=%s while rolling back: %v", ci.ID, lw.lid, err)
+				return err
+			}
+			continue
 		}
-		if gerr != nil {
-			l.logger.Warnf("AppendRecords: got the error=%v, but would be able to write some data for logID=%s, added=%d", gerr, lid, added)
+		rc, err := l.ChnkProvider.GetOpenedChunk(context.Background(), ci.ID, false)
+		if err == nil {
+			err = rc.Value().TruncateRecords(lw.last.RecordsCount)
+			l.ChnkProvider.ReleaseChunk(&rc)
+		}
+		if err != nil {
+			// the not committed records will become visible with the next committed write into the chunk
+			l.logger.Errorf("could not roll back the chunk ID=%s for logID=%s to %d records: %v", ci.ID, lw.lid, lw.last.RecordsCount, err)
+			return err
 		}
-		gerr = nil // disregard the error, cause we could write something
 	}
+	return nil
+}
 
-	return &solaris.AppendRecordsResult{Added: int64(added)}, gerr
+// deleteChunk deletes the chunk just created for the log, but not referred by the chunk infos
+func (l *localLog) deleteChunk(lid, cid string) {
+	if err := l.ChnkProvider.DeleteChunk(context.Background(), cid); err != nil {
+		l.logger.Warnf("could not delete the empty chunk ID=%s for logID=%s: %v", cid, lid, err)
+	}
+}
+
+// checkAppendCondition checks whether the log state matches the request expectations, if any. The last
+// is the last chunk of the log. The function must be called under the log lock, so the check is atomic with
+// the following write.
+func (l *localLog) checkAppendCondition(ctx context.Context, request *solaris.AppendRecordsRequest, last ChunkInfo) error {
+	if request.ExpectedLastRecordID != nil {
+		lastID := ""
+		if last.RecordsCount > 0 {
+			lastID = last.Max.String()
+		}
+		if lastID != *request.ExpectedLastRecordID {
+			return fmt.Errorf("the last record ID=%q of the logID=%s, but expected %q: %w", lastID, request.LogID,
+				*request.ExpectedLastRecordID, errors.ErrConflict)
+		}
+	}
+	if request.ExpectedRecordsCount != nil {
+		cis, err := l.LMStorage.GetChunks(ctx, request.LogID)
+		if err != nil && !errors.Is(err, errors.ErrNotExist) {
+			return err
+		}
+		var total int64
+		for _, ci := range cis {
+			total += int64(ci.visibleCount())
+		}
+		if total != *request.ExpectedRecordsCount {
+			return fmt.Errorf("the logID=%s has %d records, but expected %d: %w", request.LogID, total,
+				*request.ExpectedRecordsCount, errors.ErrConflict)
+		}
+	}
+	return nil
 }
 
 // QueryRecords allows to retrieve records from the Log by its ID. The function will control the limit of the result. If
 // the number of records or the cumulative payload size hit the limits the function may return fewer records than requested
 // or available. The second return parameters returns whether there are potentially more records than requested.
+// Only the records matching the request Condition are returned and counted against the limits.
 func (l *localLog) QueryRecords(ctx context.Context, request storage.QueryRecordsRequest) ([]*solaris.Record, bool, error) {
 	lid := request.LogID
 
+	rf, err := newRecordsFilter(request.Condition)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(rf.tis) == 0 {
+		// no record may match the condition
+		return nil, false, nil
+	}
+
 	// the l.lockers plays a role of limiter as well, it doesn't allow to have more than N locks available,
 	// so the l.lockers.GetOrCreate(ctx, lid) will be blocked if number of requested locks (not the number of requests!)
 	// exceeds the maximum (N) capacity.
@@ -231,25 +540,393 @@ func (l *localLog) QueryRecords(ctx context.Context, request storage.QueryRecord
 	}
 	totalSize := 0
 	res := []*solaris.Record{}
-	for idx >= 0 && idx < len(cis) && limit > len(res) {
+	for idx >= 0 && idx < len(cis) && limit > len(res) && totalSize < l.cfg.MaxBunchSize {
 		ci := cis[idx]
-		srecs, err := l.readRecords(ctx, lid, ci, request.Descending, sid, limit-len(res), &totalSize)
-		if err != nil {
-			return nil, false, err
+		if csid, ok := chunkStartID(rf.tis, ci, request.Descending, sid); ok {
+			srecs, err := l.readRecords(ctx, lid, ci, request.Descending, csid, rf.tstF, limit-len(res), &totalSize)
+			if l.isTruncatedAway(ctx, lid, ci, err) {
+				l.logger.Debugf("the chunk ID=%s of the log ID=%s is truncated away while reading, skipping it", ci.ID, lid)
+				err = nil
+			}
+			if err != nil {
+				return nil, false, err
+			}
+			res = append(res, srecs...)
 		}
-		res = append(res, srecs...)
 		idx += inc
 		sid = empty
 	}
 	return res, len(res) >= limit || totalSize >= l.cfg.MaxBunchSize, nil
 }
 
+// CountRecords returns the number of records in the Log matching the request. The request Limit is disregarded.
+// If the request has no Condition, the records are counted by the chunks meta-information only, without reading
+// the chunks data. Otherwise, only the chunks which records may match the condition ctime intervals are scanned.
+func (l *localLog) CountRecords(ctx context.Context, request storage.QueryRecordsRequest) (int64, error) {
+	lid := request.LogID
+
+	rf, err := newRecordsFilter(request.Condition)
+	if err != nil {
+		return 0, err
+	}
+	if len(rf.tis) == 0 {
+		// no record may match the condition
+		return 0, nil
+	}
+
+	var sid ulid.ULID
+	var empty ulid.ULID
+	if request.StartID != "" {
+		if err := sid.UnmarshalText(cast.StringToByteArray(request.StartID)); err != nil {
+			l.logger.Warnf("could not unmarshal startID=%s: %v", request.StartID, err)
+			return 0, fmt.Errorf("wrong startID=%q: %w", request.StartID, errors.ErrInvalid)
+		}
+	}
+
+	// see QueryRecords about the l.lockers role here
+	ll, err := l.lockers.GetOrCreate(ctx, lid)
+	if err != nil {
+		return 0, fmt.Errorf("could not obtain the log locker for id=%s: %w", lid, err)
+	}
+	defer l.lockers.Release(&ll)
+
+	cis, err := l.LMStorage.GetChunks(ctx, lid)
+	if err != nil {
+		return 0, err
+	}
+
+	var total int64
+	for _, ci := range cis {
+		csid := sid
+		if csid.Compare(empty) != 0 {
+			if request.Descending && ci.Min.Compare(sid) > 0 || !request.Descending && ci.Max.Compare(sid) < 0 {
+				// the chunk is out of the requested range
+				continue
+			}
+			if request.Descending && ci.Max.Compare(sid) <= 0 || !request.Descending && ci.Min.Compare(sid) >= 0 {
+				// the chunk is entirely in the requested range
+				csid = empty
+			}
+		}
+		if rf.all && csid.Compare(empty) == 0 {
+			total += int64(ci.visibleCount())
+			continue
+		}
+		var ok bool
+		if csid, ok = chunkStartID(rf.tis, ci, request.Descending, csid); !ok {
+			continue
+		}
+		n, err := l.countRecords(ctx, lid, ci, request.Descending, csid, rf)
+		if l.isTruncatedAway(ctx, lid, ci, err) {
+			l.logger.Debugf("the chunk ID=%s of the log ID=%s is truncated away while counting, skipping it", ci.ID, lid)
+			err = nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		total += n
+	}
+	return total, nil
+}
+
+// TruncateLog removes the log records before the request BeforeRecordID or, if it is not provided, before
+// the request BeforeTime. The chunks, which records are all before the point, are deleted. The chunk, which
+// is covered by the point partially, is kept, but its low-watermark (ChunkInfo.Min) is moved, so the readers
+// skip the records before it. The function returns the number of records removed.
+func (l *localLog) TruncateLog(ctx context.Context, request *solaris.TruncateLogRequest) (*solaris.TruncateLogResult, error) {
+	lid := request.LogID
+	var bid ulid.ULID
+	if request.BeforeRecordID != "" {
+		if err := bid.UnmarshalText(cast.StringToByteArray(request.BeforeRecordID)); err != nil {
+			return nil, fmt.Errorf("wrong beforeRecordID=%q: %w", request.BeforeRecordID, errors.ErrInvalid)
+		}
+	} else if request.BeforeTime != nil {
+		bid = timeToULID(request.BeforeTime.AsTime(), false)
+	} else {
+		return nil, fmt.Errorf("either beforeRecordID or beforeTime must be provided: %w", errors.ErrInvalid)
+	}
+
+	var removed int64
+	var dropIDs []string
+	err := l.WithLogLock(ctx, lid, func() (err error) {
+		removed, dropIDs, err = l.truncateLogLocked(ctx, lid, bid)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	// the chunks info are removed already, so the new readers will not see the chunks, and the files
+	// are deleted out of the log lock not to block the appends. The files of the chunks, which are
+	// read at the moment, are deleted by the provider when the chunks are released.
+	for _, cID := range dropIDs {
+		if err = l.ChnkProvider.DeleteChunk(ctx, cID); err != nil {
+			return nil, fmt.Errorf("could not delete the chunk ID=%s of the logID=%s: %w", cID, lid, err)
+		}
+	}
+	if removed > 0 {
+		l.logger.Infof("%d records (%d whole chunks) are truncated from the logID=%s", removed, len(dropIDs), lid)
+	}
+	return &solaris.TruncateLogResult{Removed: removed}, nil
+}
+
+// truncateLogLocked updates the chunks metadata of the log lid, so the records with IDs less than bid
+// are not visible anymore. It returns the number of records removed and the IDs of the chunks, which
+// are removed from the log entirely, so their files must be deleted. The log lock must be held.
+func (l *localLog) truncateLogLocked(ctx context.Context, lid string, bid ulid.ULID) (int64, []string, error) {
+	cis, err := l.LMStorage.GetChunks(ctx, lid)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var removed int64
+	var dropIDs []string
+	var partial []ChunkInfo
+	for _, ci := range cis {
+		if ci.Min.Compare(bid) >= 0 {
+			break
+		}
+		if ci.Max.Compare(bid) < 0 {
+			dropIDs = append(dropIDs, ci.ID)
+			removed += int64(ci.visibleCount())
+			continue
+		}
+		tci, err := l.truncateChunk(ctx, ci, bid)
+		if err != nil {
+			return 0, nil, err
+		}
+		removed += int64(ci.visibleCount() - tci.visibleCount())
+		partial = append(partial, tci)
+		break
+	}
+
+	if len(partial) > 0 {
+		if err = l.LMStorage.UpsertChunkInfos(ctx, lid, partial); err != nil {
+			return 0, nil, err
+		}
+	}
+	if len(dropIDs) > 0 {
+		if err = l.LMStorage.DeleteChunkInfos(ctx, lid, dropIDs); err != nil {
+			return 0, nil, err
+		}
+	}
+	return removed, dropIDs, nil
+}
+
+// WithLogLock runs f holding the log lock, which is held by AppendRecords and TruncateLog, so f is
+// serialized with the log writes. f must not call the log writing functions for the same log.
+func (l *localLog) WithLogLock(ctx context.Context, lid string, f func() error) error {
+	ll, err := l.lockers.GetOrCreate(ctx, lid)
+	if err != nil {
+		return fmt.Errorf("could not obtain the log locker for id=%s: %w", lid, err)
+	}
+	defer l.lockers.Release(&ll)
+	ll.Value().lock.Lock()
+	defer ll.Value().lock.Unlock()
+	return f()
+}
+
+// isTruncatedAway returns true if the err is returned for the chunk ci, which file doesn't exist, and the
+// chunk is not in the log chunks list anymore. The readers don't hold the log lock, so the chunk from
+// the chunks list read before may be deleted by TruncateLog since then, and its records are considered
+// truncated. The chunk, which is still in the list, but has no file, is missing indeed.
+func (l *localLog) isTruncatedAway(ctx context.Context, lid string, ci ChunkInfo, err error) bool {
+	if !errors.Is(err, os.ErrNotExist) {
+		return false
+	}
+	cis, cerr := l.LMStorage.GetChunks(ctx, lid)
+	if cerr != nil {
+		l.logger.Warnf("could not read the chunks of the log ID=%s to check the chunk ID=%s: %v", lid, ci.ID, cerr)
+		return false
+	}
+	return !slices.ContainsFunc(cis, func(c ChunkInfo) bool { return c.ID == ci.ID })
+}
+
+// truncateChunk returns the ci with the low-watermark moved to the first record with ID not less than bid.
+// The chunk must contain at least one visible record with ID not less than bid.
+func (l *localLog) truncateChunk(ctx context.Context, ci ChunkInfo, bid ulid.ULID) (ChunkInfo, error) {
+	rc, err := l.ChnkProvider.GetOpenedChunk(ctx, ci.ID, false)
+	if err != nil {
+		return ci, err
+	}
+	defer l.ChnkProvider.ReleaseChunk(&rc)
+
+	cr, err := rc.Value().OpenChunkReader(false)
+	if err != nil {
+		return ci, err
+	}
+	defer cr.Close()
+
+	// the records between the current low-watermark and bid are hidden, so they are excluded from the
+	// chunk records count and size
+	cr.SetStartID(ci.Min)
+	for cr.HasNext() {
+		ur, _ := cr.Next()
+		if ur.ID.Compare(bid) >= 0 {
+			ci.Min = ur.ID
+			return ci, nil
+		}
+		ci.Truncated++
+		ci.Size -= int64(len(ur.UnsafePayload))
+	}
+	return ci, fmt.Errorf("no records found in the chunk ID=%s after ID=%s: %w", ci.ID, bid, errors.ErrInternal)
+}
+
+// GetLogStats returns the log records statistics. The statistics is calculated by the log chunks
+// meta-information, so the chunks data is not read.
+func (l *localLog) GetLogStats(ctx context.Context, logID string) (*solaris.LogStats, error) {
+	cis, err := l.LMStorage.GetChunks(ctx, logID)
+	if err != nil {
+		return nil, err
+	}
+	res := &solaris.LogStats{LogID: logID}
+	for _, ci := range cis {
+		if ci.visibleCount() <= 0 {
+			continue
+		}
+		if res.Records == 0 {
+			res.FirstRecordID = ci.Min.String()
+		}
+		res.Records += int64(ci.visibleCount())
+		res.Size += ci.Size
+		res.LastRecordID = ci.Max.String()
+		res.LastAppendedAt = timestamppb.New(ulid.Time(ci.Max.Time()))
+		res.Chunks++
+	}
+	return res, nil
+}
+
+// recordsFilter contains the compiled records condition
+type recordsFilter struct {
+	// tstF returns true for the records matching the condition
+	tstF ql.ExprF[*solaris.Record]
+	// tis contains the ctime intervals the records matching the condition may belong to
+	tis []intervals.Interval[time.Time]
+	// all is true if the condition is empty, so any record matches it
+	all bool
+}
+
+func newRecordsFilter(cond string) (recordsFilter, error) {
+	expr, err := ql.Parse(cond)
+	if err != nil {
+		return recordsFilter{}, fmt.Errorf("condition=%q parse error=%v: %w", cond, err, errors.ErrInvalid)
+	}
+	// the dialect is created for every filter, since it caches the parsed payloads. The same dialect
+	// is used for the ctime intervals, so now() is the same for the records and the chunks selection
+	d := ql.NewRecordsCondDialect()
+	tstF, err := ql.BuildExprF(expr, d)
+	if err != nil {
+		return recordsFilter{}, fmt.Errorf("could not compile condition=%s: %w", cond, err)
+	}
+	ib := newCtimeIntervalBuilder(d)
+	tis, err := ib.Build(expr)
+	if err != nil {
+		return recordsFilter{}, fmt.Errorf("could not build ctime intervals for condition=%s: %w", cond, err)
+	}
+	return recordsFilter{tstF: tstF, tis: tis, all: len(expr.Or) == 0}, nil
+}
+
+// chunkStartID checks whether the chunk ci records may fall into the ctime intervals tis. If so,
+// the function returns the ID the chunk records should be read from, which is the sid or the first
+// record ID of the intervals, whichever goes later in the read direction.
+func chunkStartID(tis []intervals.Interval[time.Time], ci ChunkInfo, descending bool, sid ulid.ULID) (ulid.ULID, bool) {
+	var empty ulid.ULID
+	cti := intervals.BasisTime.Closed(ulid.Time(ci.Min.Time()), ulid.Time(ci.Max.Time()))
+	var ti intervals.Interval[time.Time]
+	found := false
+	for i := range tis {
+		idx := i
+		if descending {
+			idx = len(tis) - 1 - i
+		}
+		if _, ok := intervals.BasisTime.Intersect(tis[idx], cti); ok {
+			ti = tis[idx]
+			found = true
+			break
+		}
+	}
+	if !found {
+		return empty, false
+	}
+	if descending {
+		if ti.R.Before(cti.R) {
+			tid := timeToULID(ti.R, true)
+			if sid.Compare(empty) == 0 || tid.Compare(sid) < 0 {
+				sid = tid
+			}
+		}
+		return sid, true
+	}
+	if ti.L.After(cti.L) {
+		tid := timeToULID(ti.L, false)
+		if tid.Compare(sid) > 0 {
+			sid = tid
+		}
+	}
+	return sid, true
+}
+
+// visibleStartID returns the ID the chunk ci records should be read from, taking into account that only
+// the records from ci.Min up to ci.Max are visible. The records written into the chunk after ci.Max are not
+// committed yet, and the records before ci.Min are truncated.
+func visibleStartID(ci ChunkInfo, descending bool, sid ulid.ULID) ulid.ULID {
+	var empty ulid.ULID
+	if descending && (sid.Compare(empty) == 0 || sid.Compare(ci.Max) > 0) {
+		return ci.Max
+	}
+	if !descending && ci.Truncated > 0 && sid.Compare(ci.Min) < 0 {
+		return ci.Min
+	}
+	return sid
+}
+
+// visibleCount returns the number of the chunk records, which are not truncated
+func (ci ChunkInfo) visibleCount() int {
+	return ci.RecordsCount - ci.Truncated
+}
+
+// VisibleRecords returns the number of the log records, which are not truncated, by the log chunks cis
+func VisibleRecords(cis []ChunkInfo) int64 {
+	var records int64
+	for _, ci := range cis {
+		records += int64(ci.visibleCount())
+	}
+	return records
+}
+
+// nextULID returns the ULID which goes right after id
+func nextULID(id ulid.ULID) ulid.ULID {
+	for i := len(id) - 1; i >= 0; i-- {
+		id[i]++
+		if id[i] != 0 {
+			break
+		}
+	}
+	return id
+}
+
+// timeToULID returns the lowest (or the highest if upper is true) ULID for the millisecond of t
+func timeToULID(t time.Time, upper bool) ulid.ULID {
+	var id ulid.ULID
+	ms := uint64(0)
+	if t.After(time.UnixMilli(0)) {
+		ms = ulid.Timestamp(t)
+	}
+	_ = id.SetTime(min(ms, ulid.MaxTime()))
+	if upper {
+		for i := 6; i < len(id); i++ {
+			id[i] = 0xFF
+		}
+	}
+	return id
+}
+
 func (l *localLog) readRecords(
 	ctx context.Context,
 	lid string,
 	ci ChunkInfo,
 	descending bool,
 	sid ulid.ULID,
+	tstF ql.ExprF[*solaris.Record],
 	limit int,
 	totalSize *int,
 ) ([]*solaris.Record, error) {
@@ -265,22 +942,86 @@ func (l *localLog) readRecords(
 	}
 	defer cr.Close()
 
+	sid = visibleStartID(ci, descending, sid)
 	var empty ulid.ULID
 	if sid.Compare(empty) != 0 {
 		cr.SetStartID(sid)
 	}
 	res := []*solaris.Record{}
+	var r *solaris.Record
 	for cr.HasNext() && len(res) < limit && *totalSize < l.cfg.MaxBunchSize {
 		ur, _ := cr.Next()
-		r := new(solaris.Record)
+		if ur.ID.Compare(ci.Max) > 0 || ur.ID.Compare(ci.Min) < 0 {
+			// the record is not committed yet, or it is truncated
+			break
+		}
+		if r == nil {
+			r = new(solaris.Record)
+		}
 		r.ID = ur.ID.String()
 		r.LogID = lid
+		r.CreatedAt = timestamppb.New(ulid.Time(ur.ID.Time()))
+		// the payload is not copied until the record matches the condition, so the filtered out records
+		// cost no allocations for the payload. The r is re-used for the next record in the case.
+		r.Payload = ur.UnsafePayload
+		if !tstF(r) {
+			continue
+		}
 		r.Payload = make([]byte, len(ur.UnsafePayload))
 		copy(r.Payload, ur.UnsafePayload)
 		*totalSize += len(ur.UnsafePayload)
-		r.CreatedAt = timestamppb.New(ulid.Time(ur.ID.Time()))
 		res = append(res, r)
+		r = nil
+	}
+
+	return res, nil
+}
+
+// countRecords counts the chunk ci records matching the rf starting from sid. If rf accepts all records,
+// the records are not read, but counted by the chunk index.
+func (l *localLog) countRecords(ctx context.Context, lid string, ci ChunkInfo, descending bool, sid ulid.ULID, rf recordsFilter) (int64, error) {
+	rc, err := l.ChnkProvider.GetOpenedChunk(ctx, ci.ID, false)
+	if err != nil {
+		return 0, err
 	}
+	defer l.ChnkProvider.ReleaseChunk(&rc)
 
+	cr, err := rc.Value().OpenChunkReader(descending)
+	if err != nil {
+		return 0, err
+	}
+	defer cr.Close()
+
+	sid = visibleStartID(ci, descending, sid)
+	var empty ulid.ULID
+	if sid.Compare(empty) != 0 {
+		n := cr.SetStartID(sid)
+		if rf.all {
+			if !descending {
+				// exclude the records after ci.Max, which are not committed yet
+				n -= cr.SetStartID(nextULID(ci.Max))
+			} else {
+				// exclude the records before ci.Min, which are truncated
+				n = max(0, n-ci.Truncated)
+			}
+			return int64(n), nil
+		}
+	}
+
+	var res int64
+	var r solaris.Record
+	for cr.HasNext() {
+		ur, _ := cr.Next()
+		if ur.ID.Compare(ci.Max) > 0 || ur.ID.Compare(ci.Min) < 0 {
+			break
+		}
+		r.ID = ur.ID.String()
+		r.LogID = lid
+		r.CreatedAt = timestamppb.New(ulid.Time(ur.ID.Time()))
+		r.Payload = ur.UnsafePayload
+		if rf.tstF(&r) {
+			res++
+		}
+	}
 	return res, nil
 }
@@ -18,18 +18,25 @@ import (
 	"context"
 	rand2 "crypto/rand"
 	"fmt"
+	"github.com/oklog/ulid/v2"
 	"github.com/solarisdb/solaris/api/gen/solaris/v1"
+	"github.com/solarisdb/solaris/golibs/cast"
 	"github.com/solarisdb/solaris/golibs/container"
 	"github.com/solarisdb/solaris/golibs/errors"
 	"github.com/solarisdb/solaris/golibs/files"
 	"github.com/solarisdb/solaris/golibs/logging"
+	"github.com/solarisdb/solaris/golibs/ulidutils"
+	"github.com/solarisdb/solaris/pkg/intervals"
 	"github.com/solarisdb/solaris/pkg/storage"
 	"github.com/solarisdb/solaris/pkg/storage/chunkfs"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"math/rand"
 	"os"
+	"path/filepath"
 	"sync"
 	"testing"
+	"time"
 )
 
 func TestNewLocalLog(t *testing.T) {
@@ -65,6 +72,10 @@ func TestReadWriteSimple(t *testing.T) {
 	assert.Nil(t, err)
 	assert.False(t, more)
 	comparePayloads(t, qrecs, recs)
+	assert.Equal(t, qrecs[25].ID, res.FirstID)
+	assert.Equal(t, qrecs[99].ID, res.LastID)
+	assert.Equal(t, qrecs[25].CreatedAt.AsTime(), res.FirstCreatedAt.AsTime())
+	assert.Equal(t, qrecs[99].CreatedAt.AsTime(), res.LastCreatedAt.AsTime())
 
 	startID := qrecs[50].ID
 	qrecs, more, err = ll.QueryRecords(context.Background(), storage.QueryRecordsRequest{LogID: "l1", StartID: startID, Limit: 100000})
@@ -100,6 +111,7 @@ func TestAppendRecordsLimits(t *testing.T) {
 	ll := NewLocalLog(Config{
 		MaxRecordsLimit: 5,
 		MaxBunchSize:    5 * files.BlockSize,
+		MaxAppendSize:   5 * files.BlockSize,
 		MaxLocks:        1,
 	})
 	ll.LMStorage = newTestLogsMetaStorage()
@@ -118,12 +130,249 @@ func TestAppendRecordsLimits(t *testing.T) {
 	assert.Equal(t, 2, len(cis))
 	assert.Equal(t, 1, cis[0].RecordsCount)
 	assert.Equal(t, 1, cis[1].RecordsCount)
+	assert.Equal(t, cis[0].Min.String(), res.FirstID)
+	assert.Equal(t, cis[1].Max.String(), res.LastID)
+
+	// the request payload exceeds the MaxAppendSize
+	_, err = ll.AppendRecords(context.Background(), &solaris.AppendRecordsRequest{Records: generateRecords(6, files.BlockSize), LogID: "l1"})
+	assert.True(t, errors.Is(err, errors.ErrExhausted))
 
 	ll.Shutdown()
 	_, err = ll.AppendRecords(context.Background(), &solaris.AppendRecordsRequest{Records: recs, LogID: "l1"})
 	assert.True(t, errors.Is(err, errors.ErrClosed))
 }
 
+func TestAppendRecordsDedup(t *testing.T) {
+	dir, err := os.MkdirTemp("", "TestAppendRecordsDedup")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	p := chunkfs.NewProvider(dir, 1, chunkfs.GetDefaultConfig())
+	defer p.Close()
+
+	cfg := GetDefaultConfig()
+	cfg.DedupWindow = 100 * time.Millisecond
+	ll := NewLocalLog(cfg)
+	ll.LMStorage = newTestLogsMetaStorage()
+	ll.ChnkProvider = p
+	defer ll.Shutdown()
+
+	recs := generateRecords(3, 10)
+	res1, err := ll.AppendRecords(context.Background(), &solaris.AppendRecordsRequest{Records: recs, LogID: "l1", DedupKey: "k1"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), res1.Added)
+	res2, err := ll.AppendRecords(context.Background(), &solaris.AppendRecordsRequest{Records: recs, LogID: "l1", DedupKey: "k1"})
+	assert.Nil(t, err)
+	assert.Equal(t, res1.FirstID, res2.FirstID)
+	assert.Equal(t, res1.LastID, res2.LastID)
+	assert.Equal(t, int64(3), res2.Added)
+
+	// the key is per log
+	res2, err = ll.AppendRecords(context.Background(), &solaris.AppendRecordsRequest{Records: recs, LogID: "l2", DedupKey: "k1"})
+	assert.Nil(t, err)
+	assert.NotEqual(t, res1.FirstID, res2.FirstID)
+	n, err := ll.CountRecords(context.Background(), storage.QueryRecordsRequest{LogID: "l1"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), n)
+
+	// the key is expired
+	time.Sleep(100 * time.Millisecond)
+	res2, err = ll.AppendRecords(context.Background(), &solaris.AppendRecordsRequest{Records: recs, LogID: "l1", DedupKey: "k1"})
+	assert.Nil(t, err)
+	assert.NotEqual(t, res1.FirstID, res2.FirstID)
+	n, err = ll.CountRecords(context.Background(), storage.QueryRecordsRequest{LogID: "l1"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(6), n)
+
+	// the keys are kept when the log locker is evicted
+	ll2 := NewLocalLog(Config{MaxRecordsLimit: 100, MaxBunchSize: files.BlockSize, MaxLocks: 1, DedupWindow: time.Minute})
+	ll2.LMStorage = newTestLogsMetaStorage()
+	ll2.ChnkProvider = p
+	defer ll2.Shutdown()
+	res1, err = ll2.AppendRecords(context.Background(), &solaris.AppendRecordsRequest{Records: recs, LogID: "l3", DedupKey: "k3"})
+	assert.Nil(t, err)
+	_, err = ll2.AppendRecords(context.Background(), &solaris.AppendRecordsRequest{Records: recs, LogID: "l4", DedupKey: "k3"})
+	assert.Nil(t, err)
+	res2, err = ll2.AppendRecords(context.Background(), &solaris.AppendRecordsRequest{Records: recs, LogID: "l3", DedupKey: "k3"})
+	assert.Nil(t, err)
+	assert.Equal(t, res1.LastID, res2.LastID)
+
+	// no dedup when the window is 0
+	ll.cfg.DedupWindow = 0
+	_, err = ll.AppendRecords(context.Background(), &solaris.AppendRecordsRequest{Records: recs, LogID: "l2", DedupKey: "k2"})
+	assert.Nil(t, err)
+	_, err = ll.AppendRecords(context.Background(), &solaris.AppendRecordsRequest{Records: recs, LogID: "l2", DedupKey: "k2"})
+	assert.Nil(t, err)
+	n, err = ll.CountRecords(context.Background(), storage.QueryRecordsRequest{LogID: "l2"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(9), n)
+}
+
+func TestAppendRecordsConditional(t *testing.T) {
+	dir, err := os.MkdirTemp("", "TestAppendRecordsConditional")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	p := chunkfs.NewProvider(dir, 1, chunkfs.GetDefaultConfig())
+	defer p.Close()
+
+	ll := NewLocalLog(GetDefaultConfig())
+	ll.LMStorage = newTestLogsMetaStorage()
+	ll.ChnkProvider = p
+	defer ll.Shutdown()
+
+	// empty log
+	_, err = ll.AppendRecords(context.Background(), &solaris.AppendRecordsRequest{Records: generateRecords(1, 10), LogID: "l1",
+		ExpectedLastRecordID: cast.Ptr(ulidutils.NewID())})
+	assert.True(t, errors.Is(err, errors.ErrConflict))
+	_, err = ll.AppendRecords(context.Background(), &solaris.AppendRecordsRequest{Records: generateRecords(1, 10), LogID: "l1",
+		ExpectedRecordsCount: cast.Ptr(int64(1))})
+	assert.True(t, errors.Is(err, errors.ErrConflict))
+	res, err := ll.AppendRecords(context.Background(), &solaris.AppendRecordsRequest{Records: generateRecords(2, 10), LogID: "l1",
+		ExpectedLastRecordID: cast.Ptr(""), ExpectedRecordsCount: cast.Ptr(int64(0))})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), res.Added)
+
+	stale := res.FirstID
+	_, err = ll.AppendRecords(context.Background(), &solaris.AppendRecordsRequest{Records: generateRecords(1, 10), LogID: "l1",
+		ExpectedLastRecordID: cast.Ptr(res.LastID)})
+	assert.Nil(t, err)
+	_, err = ll.AppendRecords(context.Background(), &solaris.AppendRecordsRequest{Records: generateRecords(1, 10), LogID: "l1",
+		ExpectedLastRecordID: cast.Ptr(stale), ExpectedRecordsCount: cast.Ptr(int64(3))})
+	assert.True(t, errors.Is(err, errors.ErrConflict))
+	_, err = ll.AppendRecords(context.Background(), &solaris.AppendRecordsRequest{Records: generateRecords(1, 10), LogID: "l1",
+		ExpectedRecordsCount: cast.Ptr(int64(2))})
+	assert.True(t, errors.Is(err, errors.ErrConflict))
+	_, err = ll.AppendRecords(context.Background(), &solaris.AppendRecordsRequest{Records: generateRecords(1, 10), LogID: "l1",
+		ExpectedRecordsCount: cast.Ptr(int64(3))})
+	assert.Nil(t, err)
+
+	n, err := ll.CountRecords(context.Background(), storage.QueryRecordsRequest{LogID: "l1"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(4), n)
+}
+
+func TestAppendRecordsBatch(t *testing.T) {
+	dir, err := os.MkdirTemp("", "TestAppendRecordsBatch")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	p := chunkfs.NewProvider(dir, 1, chunkfs.Config{
+		NewSize:             files.BlockSize,
+		MaxChunkSize:        2 * files.BlockSize,
+		MaxGrowIncreaseSize: files.BlockSize,
+	})
+	defer p.Close()
+
+	ll := NewLocalLog(GetDefaultConfig())
+	ll.LMStorage = newTestLogsMetaStorage()
+	ll.ChnkProvider = p
+	defer ll.Shutdown()
+
+	recs1 := generateRecords(2, 100)
+	recs2 := generateRecords(3, 100)
+	res, err := ll.AppendRecordsBatch(context.Background(), []*solaris.AppendRecordsRequest{
+		{LogID: "l2", Records: recs2}, {LogID: "l1", Records: recs1}})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(res))
+	assert.Equal(t, int64(3), res[0].Added)
+	assert.Equal(t, int64(2), res[1].Added)
+	qrecs, _, err := ll.QueryRecords(context.Background(), storage.QueryRecordsRequest{LogID: "l1", Limit: 100})
+	assert.Nil(t, err)
+	comparePayloads(t, qrecs, recs1)
+	assert.Equal(t, res[1].LastID, qrecs[1].ID)
+
+	// the conflict in one request rolls back the whole batch
+	_, err = ll.AppendRecordsBatch(context.Background(), []*solaris.AppendRecordsRequest{
+		{LogID: "l1", Records: generateRecords(2, 100)}, {LogID: "l2", Records: generateRecords(1, 100), ExpectedRecordsCount: cast.Ptr(int64(1))}})
+	assert.True(t, errors.Is(err, errors.ErrConflict))
+
+	// the second record of l2 does not fit into a chunk, the whole batch is rolled back
+	_, err = ll.AppendRecordsBatch(context.Background(), []*solaris.AppendRecordsRequest{
+		{LogID: "l1", Records: generateRecords(2, 100)},
+		{LogID: "l2", Records: append(generateRecords(1, 100), generateRecords(1, 3*files.BlockSize)...)}})
+	assert.NotNil(t, err)
+
+	_, err = ll.AppendRecordsBatch(context.Background(), []*solaris.AppendRecordsRequest{
+		{LogID: "l1", Records: generateRecords(1, 100)}, {LogID: "l1", Records: generateRecords(1, 100)}})
+	assert.True(t, errors.Is(err, errors.ErrInvalid))
+
+	var reqs []*solaris.AppendRecordsRequest
+	for i := 0; i <= ll.cfg.MaxBatchLogs; i++ {
+		reqs = append(reqs, &solaris.AppendRecordsRequest{LogID: fmt.Sprintf("b%d", i), Records: generateRecords(1, 100)})
+	}
+	_, err = ll.AppendRecordsBatch(context.Background(), reqs)
+	assert.True(t, errors.Is(err, errors.ErrInvalid))
+	_, err = ll.AppendRecordsBatch(context.Background(), reqs[1:])
+	assert.Nil(t, err)
+
+	n, err := ll.CountRecords(context.Background(), storage.QueryRecordsRequest{LogID: "l1"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), n)
+	n, err = ll.CountRecords(context.Background(), storage.QueryRecordsRequest{LogID: "l2"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), n)
+
+	// no garbage after the rolled back records
+	recs3 := generateRecords(2, 100)
+	_, err = ll.AppendRecords(context.Background(), &solaris.AppendRecordsRequest{LogID: "l2", Records: recs3})
+	assert.Nil(t, err)
+	qrecs, _, err = ll.QueryRecords(context.Background(), storage.QueryRecordsRequest{LogID: "l2", Limit: 100})
+	assert.Nil(t, err)
+	comparePayloads(t, qrecs, append(recs2, recs3...))
+}
+
+func TestNotCommittedRecords(t *testing.T) {
+	dir, err := os.MkdirTemp("", "TestNotCommittedRecords")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	p := chunkfs.NewProvider(dir, 1, chunkfs.GetDefaultConfig())
+	defer p.Close()
+
+	ll := NewLocalLog(GetDefaultConfig())
+	ll.LMStorage = newTestLogsMetaStorage()
+	ll.ChnkProvider = p
+	defer ll.Shutdown()
+
+	recs := generateRecords(3, 100)
+	_, err = ll.AppendRecords(context.Background(), &solaris.AppendRecordsRequest{LogID: "l1", Records: recs})
+	assert.Nil(t, err)
+
+	lw, err := ll.writeRecords(context.Background(), &solaris.AppendRecordsRequest{LogID: "l1", Records: generateRecords(2, 100)})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, lw.added)
+
+	qrecs, _, err := ll.QueryRecords(context.Background(), storage.QueryRecordsRequest{LogID: "l1", Limit: 100})
+	assert.Nil(t, err)
+	comparePayloads(t, qrecs, recs)
+	qrecs, _, err = ll.QueryRecords(context.Background(), storage.QueryRecordsRequest{LogID: "l1", Descending: true, Limit: 100})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(qrecs))
+	n, err := ll.CountRecords(context.Background(), storage.QueryRecordsRequest{LogID: "l1", StartID: qrecs[1].ID})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), n)
+	n, err = ll.CountRecords(context.Background(), storage.QueryRecordsRequest{LogID: "l1", Condition: "ctime > '0'"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), n)
+
+	assert.Nil(t, ll.rollback(lw))
+	n, err = ll.CountRecords(context.Background(), storage.QueryRecordsRequest{LogID: "l1", StartID: qrecs[1].ID})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), n)
+
+	// the chunk created by the write is deleted by the rollback
+	lw, err = ll.writeRecords(context.Background(), &solaris.AppendRecordsRequest{LogID: "l2", Records: generateRecords(2, 100)})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(lw.cis))
+	fn := filepath.Join(dir, lw.cis[0].ID[len(lw.cis[0].ID)-2:], lw.cis[0].ID)
+	_, err = os.Stat(fn)
+	assert.Nil(t, err)
+	assert.Nil(t, ll.rollback(lw))
+	_, err = os.Stat(fn)
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+}
+
 func TestQueryRecords(t *testing.T) {
 	dir, err := os.MkdirTemp("", "TestQueryRecords")
 	assert.Nil(t, err)
@@ -176,6 +425,522 @@ func TestQueryRecords(t *testing.T) {
 	assert.True(t, errors.Is(err, errors.ErrClosed))
 }
 
+func TestQueryRecordsCondition(t *testing.T) {
+	dir, err := os.MkdirTemp("", "TestQueryRecordsCondition")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	p := chunkfs.NewProvider(dir, 1, chunkfs.GetDefaultConfig())
+	defer p.Close()
+
+	ll := NewLocalLog(Config{
+		MaxRecordsLimit: 10,
+		MaxBunchSize:    files.BlockSize,
+		MaxLocks:        1,
+	})
+	ll.LMStorage = newTestLogsMetaStorage()
+	ll.ChnkProvider = p
+	defer ll.Shutdown()
+
+	recs1 := generateRecords(20, 100)
+	_, err = ll.AppendRecords(context.Background(), &solaris.AppendRecordsRequest{Records: recs1, LogID: "l1"})
+	assert.Nil(t, err)
+	time.Sleep(10 * time.Millisecond)
+	split := time.Now()
+	time.Sleep(10 * time.Millisecond)
+	recs2 := generateRecords(15, 100)
+	_, err = ll.AppendRecords(context.Background(), &solaris.AppendRecordsRequest{Records: recs2, LogID: "l1"})
+	assert.Nil(t, err)
+
+	cond := fmt.Sprintf("ctime > '%d'", split.UnixNano())
+	qrecs, more, err := ll.QueryRecords(context.Background(), storage.QueryRecordsRequest{LogID: "l1", Condition: cond, Limit: 100})
+	assert.Nil(t, err)
+	assert.True(t, more)
+	comparePayloads(t, qrecs, recs2[:10])
+
+	qrecs, more, err = ll.QueryRecords(context.Background(), storage.QueryRecordsRequest{LogID: "l1", Condition: cond,
+		StartID: ulidutils.NextID(qrecs[9].ID), Limit: 100})
+	assert.Nil(t, err)
+	assert.False(t, more)
+	comparePayloads(t, qrecs, recs2[10:])
+
+	cond = fmt.Sprintf("ctime < '%d'", split.UnixNano())
+	qrecs, more, err = ll.QueryRecords(context.Background(), storage.QueryRecordsRequest{LogID: "l1", Condition: cond, Limit: 5})
+	assert.Nil(t, err)
+	assert.True(t, more)
+	comparePayloads(t, qrecs, recs1[:5])
+
+	container.SliceReverse(recs1)
+	qrecs, more, err = ll.QueryRecords(context.Background(), storage.QueryRecordsRequest{LogID: "l1", Condition: cond, Descending: true, Limit: 3})
+	assert.Nil(t, err)
+	assert.True(t, more)
+	comparePayloads(t, qrecs, recs1[:3])
+
+	_, _, err = ll.QueryRecords(context.Background(), storage.QueryRecordsRequest{LogID: "l1", Condition: "ctime <", Limit: 5})
+	assert.True(t, errors.Is(err, errors.ErrInvalid))
+	_, _, err = ll.QueryRecords(context.Background(), storage.QueryRecordsRequest{LogID: "l1", Condition: "unknown = 'a'", Limit: 5})
+	assert.True(t, errors.Is(err, errors.ErrInvalid))
+}
+
+func TestQueryRecordsJSONCondition(t *testing.T) {
+	dir, err := os.MkdirTemp("", "TestQueryRecordsJSONCondition")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	p := chunkfs.NewProvider(dir, 1, chunkfs.GetDefaultConfig())
+	defer p.Close()
+
+	ll := NewLocalLog(GetDefaultConfig())
+	ll.LMStorage = newTestLogsMetaStorage()
+	ll.ChnkProvider = p
+	defer ll.Shutdown()
+
+	var recs []*solaris.Record
+	for i := 0; i < 20; i++ {
+		recs = append(recs, &solaris.Record{Payload: []byte(fmt.Sprintf(`{"n": %d, "level": %q}`, i, []string{"info", "error"}[i%2]))})
+	}
+	recs = append(recs, &solaris.Record{Payload: []byte("not json")})
+	_, err = ll.AppendRecords(context.Background(), &solaris.AppendRecordsRequest{Records: recs, LogID: "l1"})
+	assert.Nil(t, err)
+
+	cond := "json('$.level') = 'error' AND json('$.n') >= 10"
+	qrecs, _, err := ll.QueryRecords(context.Background(), storage.QueryRecordsRequest{LogID: "l1", Condition: cond, Limit: 100})
+	assert.Nil(t, err)
+	comparePayloads(t, qrecs, []*solaris.Record{recs[11], recs[13], recs[15], recs[17], recs[19]})
+	n, err := ll.CountRecords(context.Background(), storage.QueryRecordsRequest{LogID: "l1", Condition: cond})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(5), n)
+	n, err = ll.CountRecords(context.Background(), storage.QueryRecordsRequest{LogID: "l1", Condition: "payload LIKE 'not%'"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), n)
+	n, err = ll.CountRecords(context.Background(), storage.QueryRecordsRequest{LogID: "l1", Condition: "json('$.n') MATCHES '^1[0-9]$'"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(10), n)
+
+	_, _, err = ll.QueryRecords(context.Background(), storage.QueryRecordsRequest{LogID: "l1", Condition: "json('$[') = 'a'", Limit: 5})
+	assert.True(t, errors.Is(err, errors.ErrInvalid))
+	_, _, err = ll.QueryRecords(context.Background(), storage.QueryRecordsRequest{LogID: "l1", Condition: "payload MATCHES '(a'", Limit: 5})
+	assert.True(t, errors.Is(err, errors.ErrInvalid))
+}
+
+func TestQueryRecordsCtimeIntervals(t *testing.T) {
+	dir, err := os.MkdirTemp("", "TestQueryRecordsCtimeIntervals")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	p := chunkfs.NewProvider(dir, 1, chunkfs.Config{
+		NewSize:             files.BlockSize,
+		MaxChunkSize:        2 * files.BlockSize,
+		MaxGrowIncreaseSize: files.BlockSize,
+	})
+	defer p.Close()
+
+	ll := NewLocalLog(Config{
+		MaxRecordsLimit: 100,
+		MaxBunchSize:    100 * files.BlockSize,
+		MaxLocks:        1,
+	})
+	ll.LMStorage = newTestLogsMetaStorage()
+	ll.ChnkProvider = p
+	defer ll.Shutdown()
+
+	var points []time.Time
+	var recs [][]*solaris.Record
+	for i := 0; i < 3; i++ {
+		points = append(points, time.Now())
+		time.Sleep(10 * time.Millisecond)
+		rs := generateRecords(6, 2500)
+		_, err = ll.AppendRecords(context.Background(), &solaris.AppendRecordsRequest{Records: rs, LogID: "l1"})
+		assert.Nil(t, err)
+		recs = append(recs, rs)
+		time.Sleep(10 * time.Millisecond)
+	}
+	cis, _ := ll.LMStorage.GetChunks(context.Background(), "l1")
+	assert.Equal(t, 6, len(cis))
+
+	cond := fmt.Sprintf("ctime > '%d' AND ctime < '%d'", points[1].UnixNano(), points[2].UnixNano())
+	qrecs, more, err := ll.QueryRecords(context.Background(), storage.QueryRecordsRequest{LogID: "l1", Condition: cond, Limit: 100})
+	assert.Nil(t, err)
+	assert.False(t, more)
+	comparePayloads(t, qrecs, recs[1])
+
+	container.SliceReverse(recs[1])
+	qrecs, _, err = ll.QueryRecords(context.Background(), storage.QueryRecordsRequest{LogID: "l1", Condition: cond, Descending: true, Limit: 100})
+	assert.Nil(t, err)
+	comparePayloads(t, qrecs, recs[1])
+
+	cond = fmt.Sprintf("ctime > '%d' AND ctime < '%d'", points[2].UnixNano(), points[1].UnixNano())
+	qrecs, more, err = ll.QueryRecords(context.Background(), storage.QueryRecordsRequest{LogID: "l1", Condition: cond, Limit: 100})
+	assert.Nil(t, err)
+	assert.False(t, more)
+	assert.Equal(t, 0, len(qrecs))
+
+	tz := time.FixedZone("", 2*3600)
+	cond = fmt.Sprintf("ctime > date('%s', '+02:00') AND ctime < date('%s', '+02:00')",
+		points[1].In(tz).Format("02/01/2006 15:04:05.000"), points[2].In(tz).Format("02/01/2006 15:04:05.000"))
+	qrecs, _, err = ll.QueryRecords(context.Background(), storage.QueryRecordsRequest{LogID: "l1", Condition: cond, Descending: true, Limit: 100})
+	assert.Nil(t, err)
+	comparePayloads(t, qrecs, recs[1])
+
+	n, err := ll.CountRecords(context.Background(), storage.QueryRecordsRequest{LogID: "l1", Condition: "ctime > now() - '1h'"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(18), n)
+	qrecs, _, err = ll.QueryRecords(context.Background(), storage.QueryRecordsRequest{LogID: "l1", Condition: "ctime < now() - '1h' + '10m'", Limit: 100})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(qrecs))
+}
+
+func TestCountRecords(t *testing.T) {
+	dir, err := os.MkdirTemp("", "TestCountRecords")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	p := chunkfs.NewProvider(dir, 1, chunkfs.Config{
+		NewSize:             files.BlockSize,
+		MaxChunkSize:        2 * files.BlockSize,
+		MaxGrowIncreaseSize: files.BlockSize,
+	})
+	defer p.Close()
+
+	ll := NewLocalLog(Config{
+		MaxRecordsLimit: 100,
+		MaxBunchSize:    100 * files.BlockSize,
+		MaxLocks:        1,
+	})
+	ll.LMStorage = newTestLogsMetaStorage()
+	ll.ChnkProvider = p
+	defer ll.Shutdown()
+
+	var points []time.Time
+	var ids []string
+	for i := 0; i < 3; i++ {
+		points = append(points, time.Now())
+		time.Sleep(10 * time.Millisecond)
+		_, err = ll.AppendRecords(context.Background(), &solaris.AppendRecordsRequest{Records: generateRecords(6, 2500), LogID: "l1"})
+		assert.Nil(t, err)
+		time.Sleep(10 * time.Millisecond)
+	}
+	qrecs, _, err := ll.QueryRecords(context.Background(), storage.QueryRecordsRequest{LogID: "l1", Limit: 100})
+	assert.Nil(t, err)
+	for _, r := range qrecs {
+		ids = append(ids, r.ID)
+	}
+
+	// no condition, the chunks must not be touched
+	ml := NewLocalLog(Config{MaxLocks: 1})
+	ml.LMStorage = ll.LMStorage
+	defer ml.Shutdown()
+	n, err := ml.CountRecords(context.Background(), storage.QueryRecordsRequest{LogID: "l1"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(18), n)
+
+	n, err = ll.CountRecords(context.Background(), storage.QueryRecordsRequest{LogID: "l1", StartID: ids[3]})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(15), n)
+	n, err = ll.CountRecords(context.Background(), storage.QueryRecordsRequest{LogID: "l1", StartID: ids[3], Descending: true})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(4), n)
+
+	cond := fmt.Sprintf("ctime > '%d'", points[1].UnixNano())
+	n, err = ll.CountRecords(context.Background(), storage.QueryRecordsRequest{LogID: "l1", Condition: cond})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(12), n)
+	n, err = ll.CountRecords(context.Background(), storage.QueryRecordsRequest{LogID: "l1", Condition: cond, StartID: ids[8], Descending: true})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), n)
+	n, err = ll.CountRecords(context.Background(), storage.QueryRecordsRequest{LogID: "l1", Condition: "ctime < '1'"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), n)
+
+	_, err = ll.CountRecords(context.Background(), storage.QueryRecordsRequest{LogID: "l1", Condition: "ctime <"})
+	assert.True(t, errors.Is(err, errors.ErrInvalid))
+	_, err = ll.CountRecords(context.Background(), storage.QueryRecordsRequest{LogID: "l1", StartID: "abc"})
+	assert.True(t, errors.Is(err, errors.ErrInvalid))
+}
+
+func TestTruncateLog(t *testing.T) {
+	dir, err := os.MkdirTemp("", "TestTruncateLog")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	p := chunkfs.NewProvider(dir, 1, chunkfs.Config{
+		NewSize:             files.BlockSize,
+		MaxChunkSize:        2 * files.BlockSize,
+		MaxGrowIncreaseSize: files.BlockSize,
+	})
+	defer p.Close()
+
+	ll := NewLocalLog(GetDefaultConfig())
+	ll.LMStorage = newTestLogsMetaStorage()
+	ll.ChnkProvider = p
+	defer ll.Shutdown()
+
+	ctx := context.Background()
+	recs := generateRecords(18, 2500)
+	_, err = ll.AppendRecords(ctx, &solaris.AppendRecordsRequest{Records: recs, LogID: "l1"})
+	assert.Nil(t, err)
+	qrecs, _, err := ll.QueryRecords(ctx, storage.QueryRecordsRequest{LogID: "l1", Limit: 100})
+	assert.Nil(t, err)
+	assert.Len(t, qrecs, 18)
+	before, _ := ll.LMStorage.GetChunks(ctx, "l1")
+	assert.True(t, len(before) > 2)
+	assert.True(t, before[1].RecordsCount > 1)
+
+	_, err = ll.TruncateLog(ctx, &solaris.TruncateLogRequest{LogID: "l1"})
+	assert.True(t, errors.Is(err, errors.ErrInvalid))
+	_, err = ll.TruncateLog(ctx, &solaris.TruncateLogRequest{LogID: "l1", BeforeRecordID: "abc"})
+	assert.True(t, errors.Is(err, errors.ErrInvalid))
+
+	// the first chunk is deleted, the second one is truncated partially
+	k := before[0].RecordsCount + 1
+	res, err := ll.TruncateLog(ctx, &solaris.TruncateLogRequest{LogID: "l1", BeforeRecordID: qrecs[k].ID})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(k), res.Removed)
+	after, _ := ll.LMStorage.GetChunks(ctx, "l1")
+	assert.Equal(t, len(before)-1, len(after))
+	assert.Equal(t, qrecs[k].ID, after[0].Min.String())
+	assert.Equal(t, 1, after[0].Truncated)
+	_, err = p.GetOpenedChunk(ctx, before[0].ID, false)
+	assert.NotNil(t, err)
+
+	trecs, _, err := ll.QueryRecords(ctx, storage.QueryRecordsRequest{LogID: "l1", Limit: 100})
+	assert.Nil(t, err)
+	comparePayloads(t, trecs, recs[k:])
+	trecs, _, err = ll.QueryRecords(ctx, storage.QueryRecordsRequest{LogID: "l1", Limit: 100, Descending: true})
+	assert.Nil(t, err)
+	assert.Len(t, trecs, 18-k)
+	assert.Equal(t, qrecs[k].ID, trecs[len(trecs)-1].ID)
+	trecs, _, err = ll.QueryRecords(ctx, storage.QueryRecordsRequest{LogID: "l1", Limit: 100, StartID: qrecs[0].ID})
+	assert.Nil(t, err)
+	assert.Len(t, trecs, 18-k)
+
+	n, err := ll.CountRecords(ctx, storage.QueryRecordsRequest{LogID: "l1"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(18-k), n)
+	n, err = ll.CountRecords(ctx, storage.QueryRecordsRequest{LogID: "l1", StartID: qrecs[k+1].ID, Descending: true})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), n)
+	n, err = ll.CountRecords(ctx, storage.QueryRecordsRequest{LogID: "l1", Condition: "ctime > '0'"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(18-k), n)
+
+	// nothing to truncate
+	res, err = ll.TruncateLog(ctx, &solaris.TruncateLogRequest{LogID: "l1", BeforeRecordID: qrecs[0].ID})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), res.Removed)
+
+	// the appends are continued after the whole log is truncated
+	res, err = ll.TruncateLog(ctx, &solaris.TruncateLogRequest{LogID: "l1", BeforeTime: timestamppb.New(time.Now().Add(time.Second))})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(18-k), res.Removed)
+	n, err = ll.CountRecords(ctx, storage.QueryRecordsRequest{LogID: "l1"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), n)
+	_, err = ll.AppendRecords(ctx, &solaris.AppendRecordsRequest{Records: generateRecords(2, 100), LogID: "l1"})
+	assert.Nil(t, err)
+	n, err = ll.CountRecords(ctx, storage.QueryRecordsRequest{LogID: "l1"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), n)
+}
+
+func TestTruncateLogChunkInUse(t *testing.T) {
+	dir, err := os.MkdirTemp("", "TestTruncateLogChunkInUse")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	p := chunkfs.NewProvider(dir, 2, chunkfs.Config{
+		NewSize:             files.BlockSize,
+		MaxChunkSize:        2 * files.BlockSize,
+		MaxGrowIncreaseSize: files.BlockSize,
+	})
+	defer p.Close()
+
+	ll := NewLocalLog(GetDefaultConfig())
+	ll.LMStorage = newTestLogsMetaStorage()
+	ll.ChnkProvider = p
+	defer ll.Shutdown()
+
+	ctx := context.Background()
+	recs := generateRecords(18, 2500)
+	_, err = ll.AppendRecords(ctx, &solaris.AppendRecordsRequest{Records: recs, LogID: "l1"})
+	assert.Nil(t, err)
+	qrecs, _, err := ll.QueryRecords(ctx, storage.QueryRecordsRequest{LogID: "l1", Limit: 100})
+	assert.Nil(t, err)
+	before, _ := ll.LMStorage.GetChunks(ctx, "l1")
+	assert.True(t, len(before) > 2)
+
+	// the chunk is read at the moment, so its file is deleted when the chunk is released
+	rc, err := p.GetOpenedChunk(ctx, before[0].ID, false)
+	assert.Nil(t, err)
+	fn := filepath.Join(dir, before[0].ID[len(before[0].ID)-2:], before[0].ID)
+	k := before[0].RecordsCount
+	res, err := ll.TruncateLog(ctx, &solaris.TruncateLogRequest{LogID: "l1", BeforeRecordID: qrecs[k].ID})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(k), res.Removed)
+	_, err = os.Stat(fn)
+	assert.Nil(t, err)
+
+	_, err = ll.AppendRecords(ctx, &solaris.AppendRecordsRequest{Records: generateRecords(1, 100), LogID: "l1"})
+	assert.Nil(t, err)
+	n, err := ll.CountRecords(ctx, storage.QueryRecordsRequest{LogID: "l1"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(18-k+1), n)
+
+	p.ReleaseChunk(&rc)
+	_, err = os.Stat(fn)
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+}
+
+// testTruncatingStorage calls onGetChunks after the chunks list is read, but before it is returned
+type testTruncatingStorage struct {
+	LogsMetaStorage
+	onGetChunks func()
+}
+
+func (ts *testTruncatingStorage) GetChunks(ctx context.Context, logID string) ([]ChunkInfo, error) {
+	cis, err := ts.LogsMetaStorage.GetChunks(ctx, logID)
+	if ts.onGetChunks != nil {
+		f := ts.onGetChunks
+		ts.onGetChunks = nil
+		f()
+	}
+	return cis, err
+}
+
+func TestTruncateLogWhileReading(t *testing.T) {
+	dir, err := os.MkdirTemp("", "TestTruncateLogWhileReading")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	p := chunkfs.NewProvider(dir, 1, chunkfs.Config{
+		NewSize:             files.BlockSize,
+		MaxChunkSize:        2 * files.BlockSize,
+		MaxGrowIncreaseSize: files.BlockSize,
+	})
+	defer p.Close()
+
+	ts := &testTruncatingStorage{LogsMetaStorage: newTestLogsMetaStorage()}
+	ll := NewLocalLog(GetDefaultConfig())
+	ll.LMStorage = ts
+	ll.ChnkProvider = p
+	defer ll.Shutdown()
+
+	ctx := context.Background()
+	recs := generateRecords(18, 2500)
+	_, err = ll.AppendRecords(ctx, &solaris.AppendRecordsRequest{Records: recs, LogID: "l1"})
+	assert.Nil(t, err)
+	qrecs, _, err := ll.QueryRecords(ctx, storage.QueryRecordsRequest{LogID: "l1", Limit: 100})
+	assert.Nil(t, err)
+	cis, _ := ts.GetChunks(ctx, "l1")
+	assert.True(t, len(cis) > 2)
+
+	// the first chunk is deleted by the truncation after the readers got the chunks list
+	k := cis[0].RecordsCount
+	truncate := func() {
+		_, err := ll.TruncateLog(ctx, &solaris.TruncateLogRequest{LogID: "l1", BeforeRecordID: qrecs[k].ID})
+		assert.Nil(t, err)
+	}
+	ts.onGetChunks = truncate
+	trecs, _, err := ll.QueryRecords(ctx, storage.QueryRecordsRequest{LogID: "l1", Limit: 100})
+	assert.Nil(t, err)
+	comparePayloads(t, trecs, recs[k:])
+
+	_, err = ll.AppendRecords(ctx, &solaris.AppendRecordsRequest{Records: generateRecords(18, 2500), LogID: "l1"})
+	assert.Nil(t, err)
+	qrecs, _, err = ll.QueryRecords(ctx, storage.QueryRecordsRequest{LogID: "l1", Limit: 100})
+	assert.Nil(t, err)
+	cis, _ = ts.GetChunks(ctx, "l1")
+	k = cis[0].RecordsCount - cis[0].Truncated
+	ts.onGetChunks = truncate
+	n, err := ll.CountRecords(ctx, storage.QueryRecordsRequest{LogID: "l1", Condition: "ctime > '0'"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(len(qrecs)-k), n)
+
+	// the chunk file is missing, but the chunk is not truncated
+	cis, _ = ts.GetChunks(ctx, "l1")
+	assert.Nil(t, p.DeleteChunk(ctx, cis[0].ID))
+	_, _, err = ll.QueryRecords(ctx, storage.QueryRecordsRequest{LogID: "l1", Limit: 100})
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+	_, err = ll.CountRecords(ctx, storage.QueryRecordsRequest{LogID: "l1", Condition: "ctime > '0'"})
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+}
+
+func TestGetLogStats(t *testing.T) {
+	dir, err := os.MkdirTemp("", "TestGetLogStats")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	p := chunkfs.NewProvider(dir, 1, chunkfs.Config{
+		NewSize:             files.BlockSize,
+		MaxChunkSize:        2 * files.BlockSize,
+		MaxGrowIncreaseSize: files.BlockSize,
+	})
+	defer p.Close()
+
+	ll := NewLocalLog(GetDefaultConfig())
+	ll.LMStorage = newTestLogsMetaStorage()
+	ll.ChnkProvider = p
+	defer ll.Shutdown()
+
+	ctx := context.Background()
+	_, err = ll.GetLogStats(ctx, "l1")
+	assert.True(t, errors.Is(err, errors.ErrNotExist))
+
+	recs := generateRecords(10, 2000)
+	ar, err := ll.AppendRecords(ctx, &solaris.AppendRecordsRequest{Records: recs, LogID: "l1"})
+	assert.Nil(t, err)
+	cis, _ := ll.LMStorage.GetChunks(ctx, "l1")
+	assert.True(t, len(cis) > 1)
+
+	ls, err := ll.GetLogStats(ctx, "l1")
+	assert.Nil(t, err)
+	assert.Equal(t, "l1", ls.LogID)
+	assert.Equal(t, int64(10), ls.Records)
+	assert.Equal(t, int64(10*2000), ls.Size)
+	assert.Equal(t, ar.FirstID, ls.FirstRecordID)
+	assert.Equal(t, ar.LastID, ls.LastRecordID)
+	assert.Equal(t, ar.LastCreatedAt.AsTime(), ls.LastAppendedAt.AsTime())
+	assert.Equal(t, int64(len(cis)), ls.Chunks)
+
+	// the statistics is consistent with the log truncation
+	qrecs, _, err := ll.QueryRecords(ctx, storage.QueryRecordsRequest{LogID: "l1", Limit: 100})
+	assert.Nil(t, err)
+	k := cis[0].RecordsCount + 1
+	_, err = ll.TruncateLog(ctx, &solaris.TruncateLogRequest{LogID: "l1", BeforeRecordID: qrecs[k].ID})
+	assert.Nil(t, err)
+	ls, err = ll.GetLogStats(ctx, "l1")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(10-k), ls.Records)
+	assert.Equal(t, int64((10-k)*2000), ls.Size)
+	assert.Equal(t, qrecs[k].ID, ls.FirstRecordID)
+	assert.Equal(t, ar.LastID, ls.LastRecordID)
+	assert.Equal(t, int64(len(cis)-1), ls.Chunks)
+}
+
+func TestChunkStartID(t *testing.T) {
+	now := time.Now()
+	ci := ChunkInfo{Min: timeToULID(now, false), Max: timeToULID(now.Add(time.Second), true)}
+	bt := intervals.BasisTime
+	var empty ulid.ULID
+
+	_, ok := chunkStartID([]intervals.Interval[time.Time]{bt.Open(now.Add(2*time.Second), bt.Max)}, ci, false, empty)
+	assert.False(t, ok)
+
+	tis := []intervals.Interval[time.Time]{bt.Open(now.Add(100*time.Millisecond), now.Add(200*time.Millisecond))}
+	sid, ok := chunkStartID(tis, ci, false, empty)
+	assert.True(t, ok)
+	assert.Equal(t, timeToULID(now.Add(100*time.Millisecond), false), sid)
+
+	sid, ok = chunkStartID(tis, ci, true, empty)
+	assert.True(t, ok)
+	assert.Equal(t, timeToULID(now.Add(200*time.Millisecond), true), sid)
+
+	later := timeToULID(now.Add(500*time.Millisecond), false)
+	sid, ok = chunkStartID(tis, ci, false, later)
+	assert.True(t, ok)
+	assert.Equal(t, later, sid)
+}
+
 func TestConcurrentMess(t *testing.T) {
 	dir, err := os.MkdirTemp("", "TestConcurrentMess2")
 	assert.Nil(t, err)
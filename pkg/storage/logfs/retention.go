@@ -0,0 +1,244 @@
+// Copyright 2024 The Solaris Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logfs
+
+import (
+	"context"
+	"fmt"
+	"github.com/oklog/ulid/v2"
+	"github.com/solarisdb/solaris/api/gen/solaris/v1"
+	"github.com/solarisdb/solaris/golibs/logging"
+	"github.com/solarisdb/solaris/pkg/storage"
+	"sync"
+	"time"
+)
+
+type (
+	// RetentionStorage interface describes the meta storage operations for applying the logs retention policies
+	RetentionStorage interface {
+		// GetLogs returns up to limit logs, not marked deleted, with the IDs greater than afterID in
+		// the ascending order of the IDs. If ownRetentionOnly is true, only the logs with their own
+		// retention policy are returned.
+		GetLogs(ctx context.Context, afterID string, limit int, ownRetentionOnly bool) ([]*solaris.Log, error)
+	}
+
+	// Retainer applies the logs retention policies. It runs in background periodically, and it drops
+	// the whole chunks of the logs, which records are out of the log retention limits. The last chunk
+	// of a log is never dropped, so the Retainer doesn't interfere with the appends to the log. The chunks
+	// are dropped by truncating the log, so the chunks metadata is changed under the log lock, but the
+	// chunk files are deleted after the lock is released.
+	Retainer struct {
+		LMStorage LogsMetaStorage  `inject:""`
+		RStorage  RetentionStorage `inject:""`
+		Log       storage.Log      `inject:""`
+
+		cfg    RetainerConfig
+		logger logging.Logger
+		cancel context.CancelFunc
+		wg     sync.WaitGroup
+
+		lock  sync.Mutex
+		stats RetainerStats
+	}
+
+	// RetainerConfig defines the Retainer settings
+	RetainerConfig struct {
+		// Interval defines how often the retention policies are applied. The value 0 disables the background job.
+		Interval time.Duration
+		// Default is the retention policy for the logs, which have no their own one
+		Default *solaris.Retention
+		// BatchSize defines how many logs are read from the meta storage at a time
+		BatchSize int
+	}
+
+	// RetainerStats contains the Retainer progress counters
+	RetainerStats struct {
+		// Runs is the number of the retention rounds done
+		Runs int64
+		// DroppedChunks is the number of chunks dropped from the logs
+		DroppedChunks int64
+		// DroppedRecords is the number of records dropped from the logs
+		DroppedRecords int64
+		// Errors is the number of errors happened
+		Errors int64
+		// LastRunAt is the time when the last retention round was finished
+		LastRunAt time.Time
+		// LastError contains the last error happened, if any
+		LastError string
+	}
+)
+
+// NewRetainer creates the new Retainer object for the cfg provided
+func NewRetainer(cfg RetainerConfig) *Retainer {
+	r := new(Retainer)
+	r.cfg = cfg
+	if r.cfg.BatchSize <= 0 {
+		r.cfg.BatchSize = 100
+	}
+	r.logger = logging.NewLogger("logfs.Retainer")
+	return r
+}
+
+// Init implements linker.Initializer
+func (r *Retainer) Init(ctx context.Context) error {
+	if r.cfg.Interval <= 0 {
+		r.logger.Infof("Background retention is disabled")
+		return nil
+	}
+	r.logger.Infof("Starting background retention with interval=%s, default policy=%v", r.cfg.Interval, r.cfg.Default)
+	var cctx context.Context
+	cctx, r.cancel = context.WithCancel(context.Background())
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		ticker := time.NewTicker(r.cfg.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-cctx.Done():
+				return
+			case <-ticker.C:
+				_, _ = r.Apply(cctx)
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown implements linker.Shutdowner
+func (r *Retainer) Shutdown() {
+	r.logger.Infof("Shutting down.")
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+}
+
+// Apply runs one retention round over all the logs, and returns the number of chunks dropped.
+// The logs, which could not be processed due to an error, are tried again in the next round.
+// If there is no default retention policy, only the logs with their own policy are processed.
+func (r *Retainer) Apply(ctx context.Context) (int, error) {
+	var resErr error
+	dropped := 0
+	afterID := ""
+	ownRetentionOnly := !isRetentionSet(r.cfg.Default)
+	for ctx.Err() == nil {
+		logs, err := r.RStorage.GetLogs(ctx, afterID, r.cfg.BatchSize, ownRetentionOnly)
+		if err != nil {
+			r.onError(fmt.Errorf("could not get logs: %w", err))
+			resErr = err
+			break
+		}
+		for _, log := range logs {
+			n, err := r.applyToLog(ctx, log, time.Now())
+			if err != nil {
+				r.onError(err)
+				resErr = err
+			}
+			dropped += n
+		}
+		if len(logs) < r.cfg.BatchSize {
+			break
+		}
+		afterID = logs[len(logs)-1].ID
+	}
+
+	r.lock.Lock()
+	r.stats.Runs++
+	r.stats.LastRunAt = time.Now()
+	r.lock.Unlock()
+	if dropped > 0 {
+		r.logger.Infof("%d chunks dropped by the retention policies", dropped)
+	}
+	return dropped, resErr
+}
+
+// Stats returns the Retainer progress counters
+func (r *Retainer) Stats() RetainerStats {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.stats
+}
+
+// applyToLog drops the log chunks which are out of the log retention policy limits
+func (r *Retainer) applyToLog(ctx context.Context, log *solaris.Log, now time.Time) (int, error) {
+	rp := log.Retention
+	if rp == nil {
+		rp = r.cfg.Default
+	}
+	if !isRetentionSet(rp) {
+		return 0, nil
+	}
+	cis, err := r.LMStorage.GetChunks(ctx, log.ID)
+	if err != nil {
+		return 0, fmt.Errorf("could not get chunks of the log ID=%s: %w", log.ID, err)
+	}
+	drop := chunksOutOfRetention(cis, rp, now)
+	if len(drop) == 0 {
+		return 0, nil
+	}
+
+	// the log is truncated up to the first record of the first chunk kept
+	res, err := r.Log.TruncateLog(ctx, &solaris.TruncateLogRequest{LogID: log.ID, BeforeRecordID: cis[len(drop)].Min.String()})
+	if err != nil {
+		return 0, fmt.Errorf("could not truncate the log ID=%s: %w", log.ID, err)
+	}
+	r.lock.Lock()
+	r.stats.DroppedChunks += int64(len(drop))
+	r.stats.DroppedRecords += res.Removed
+	r.lock.Unlock()
+	r.logger.Debugf("%d chunks (%d records) are dropped from the log ID=%s", len(drop), res.Removed, log.ID)
+	return len(drop), nil
+}
+
+func (r *Retainer) onError(err error) {
+	r.logger.Warnf("retention error: %v", err)
+	r.lock.Lock()
+	r.stats.Errors++
+	r.stats.LastError = err.Error()
+	r.lock.Unlock()
+}
+
+// isRetentionSet returns true if the retention policy rp defines at least one limit
+func isRetentionSet(rp *solaris.Retention) bool {
+	return rp != nil && (rp.MaxAgeSeconds > 0 || rp.MaxRecords > 0 || rp.MaxSize > 0)
+}
+
+// chunksOutOfRetention returns the chunks of cis (sorted by IDs), which records are out of the retention
+// policy rp limits. A chunk is out of the limits if all its records are older than the maximum age, or if
+// the newer chunks contain the maximum number of records or the maximum size already. The last chunk
+// is never selected.
+func chunksOutOfRetention(cis []ChunkInfo, rp *solaris.Retention, now time.Time) []ChunkInfo {
+	if len(cis) < 2 {
+		return nil
+	}
+	var minMax ulid.ULID
+	if rp.MaxAgeSeconds > 0 {
+		_ = minMax.SetTime(ulid.Timestamp(now.Add(-time.Duration(rp.MaxAgeSeconds) * time.Second)))
+	}
+	records := int64(cis[len(cis)-1].visibleCount())
+	size := cis[len(cis)-1].Size
+	for i := len(cis) - 2; i >= 0; i-- {
+		ci := cis[i]
+		if ci.Max.Compare(minMax) < 0 ||
+			(rp.MaxRecords > 0 && records >= rp.MaxRecords) ||
+			(rp.MaxSize > 0 && size >= rp.MaxSize) {
+			return cis[:i+1]
+		}
+		records += int64(ci.visibleCount())
+		size += ci.Size
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+// Copyright 2024 The Solaris Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logfs
+
+import (
+	"context"
+	"github.com/oklog/ulid/v2"
+	"github.com/solarisdb/solaris/api/gen/solaris/v1"
+	"github.com/solarisdb/solaris/golibs/files"
+	"github.com/solarisdb/solaris/pkg/storage"
+	"github.com/solarisdb/solaris/pkg/storage/chunkfs"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestChunksOutOfRetention(t *testing.T) {
+	now := time.Now()
+	ci := func(id string, age time.Duration, records int, size int64) ChunkInfo {
+		return ChunkInfo{ID: id, Max: ulid.MustNew(ulid.Timestamp(now.Add(-age)), nil), RecordsCount: records, Size: size}
+	}
+	cis := []ChunkInfo{ci("1", 3*time.Hour, 10, 100), ci("2", 2*time.Hour, 10, 100), ci("3", time.Hour, 10, 100), ci("4", 0, 10, 100)}
+
+	assert.Nil(t, chunksOutOfRetention(cis, &solaris.Retention{}, now))
+	assert.Nil(t, chunksOutOfRetention(cis[3:], &solaris.Retention{MaxRecords: 1}, now))
+	assert.Equal(t, cis[:1], chunksOutOfRetention(cis, &solaris.Retention{MaxAgeSeconds: int64((150 * time.Minute).Seconds())}, now))
+	assert.Equal(t, cis[:3], chunksOutOfRetention(cis, &solaris.Retention{MaxAgeSeconds: 1}, now))
+	assert.Equal(t, cis[:2], chunksOutOfRetention(cis, &solaris.Retention{MaxRecords: 20}, now))
+	assert.Equal(t, cis[:1], chunksOutOfRetention(cis, &solaris.Retention{MaxRecords: 21}, now))
+	assert.Nil(t, chunksOutOfRetention(cis, &solaris.Retention{MaxRecords: 31}, now))
+	assert.Equal(t, cis[:3], chunksOutOfRetention(cis, &solaris.Retention{MaxSize: 1}, now))
+	assert.Equal(t, cis[:2], chunksOutOfRetention(cis, &solaris.Retention{MaxSize: 150, MaxRecords: 1000}, now))
+}
+
+func TestRetainer_Apply(t *testing.T) {
+	dir, err := os.MkdirTemp("", "TestRetainer_Apply")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	p := chunkfs.NewProvider(dir, 2, chunkfs.Config{
+		NewSize:             files.BlockSize,
+		MaxChunkSize:        2 * files.BlockSize,
+		MaxGrowIncreaseSize: files.BlockSize,
+	})
+	defer p.Close()
+
+	lms := newTestLogsMetaStorage()
+	ll := NewLocalLog(GetDefaultConfig())
+	ll.LMStorage = lms
+	ll.ChnkProvider = p
+	defer ll.Shutdown()
+
+	ctx := context.Background()
+	recs := generateRecords(20, files.BlockSize/2)
+	for _, lid := range []string{"l1", "l2"} {
+		for _, r := range recs {
+			_, err = ll.AppendRecords(ctx, &solaris.AppendRecordsRequest{Records: []*solaris.Record{r}, LogID: lid})
+			assert.Nil(t, err)
+		}
+	}
+	// the l2 own policy overrides the default one
+	lms.setRetention("l2", &solaris.Retention{})
+	before, _ := lms.GetChunks(ctx, "l1")
+	assert.True(t, len(before) > 3)
+
+	r := NewRetainer(RetainerConfig{Default: &solaris.Retention{MaxRecords: 5}, BatchSize: 1})
+	r.LMStorage = lms
+	r.RStorage = lms
+	r.Log = ll
+	n, err := r.Apply(ctx)
+	assert.Nil(t, err)
+	assert.True(t, n > 0)
+
+	after, _ := lms.GetChunks(ctx, "l1")
+	assert.Equal(t, len(before)-n, len(after))
+	assert.Equal(t, before[len(before)-1], after[len(after)-1])
+	total := 0
+	for _, ci := range after {
+		total += ci.RecordsCount
+	}
+	assert.True(t, total >= 5)
+	assert.True(t, total-after[0].RecordsCount < 5)
+	for _, ci := range before[:n] {
+		_, err = p.GetOpenedChunk(ctx, ci.ID, false)
+		assert.NotNil(t, err)
+	}
+
+	qrecs, _, err := ll.QueryRecords(ctx, storage.QueryRecordsRequest{LogID: "l1", Limit: 100})
+	assert.Nil(t, err)
+	comparePayloads(t, qrecs, recs[len(recs)-total:])
+	cis, _ := lms.GetChunks(ctx, "l2")
+	assert.Equal(t, len(before), len(cis))
+
+	stats := r.Stats()
+	assert.Equal(t, int64(1), stats.Runs)
+	assert.Equal(t, int64(n), stats.DroppedChunks)
+	assert.Equal(t, int64(len(recs)-total), stats.DroppedRecords)
+	assert.Equal(t, int64(0), stats.Errors)
+
+	n, err = r.Apply(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, n)
+}
+
+// testRetentionStorage remembers the logs returned by GetLogs
+type testRetentionStorage struct {
+	RetentionStorage
+	returned []string
+}
+
+func (rs *testRetentionStorage) GetLogs(ctx context.Context, afterID string, limit int, ownRetentionOnly bool) ([]*solaris.Log, error) {
+	logs, err := rs.RetentionStorage.GetLogs(ctx, afterID, limit, ownRetentionOnly)
+	for _, log := range logs {
+		rs.returned = append(rs.returned, log.ID)
+	}
+	return logs, err
+}
+
+func TestRetainer_ApplyNoDefault(t *testing.T) {
+	lms := newTestLogsMetaStorage()
+	ctx := context.Background()
+	for _, lid := range []string{"l1", "l2", "l3"} {
+		assert.Nil(t, lms.UpsertChunkInfos(ctx, lid, []ChunkInfo{{ID: lid + "c1", RecordsCount: 1}}))
+	}
+	lms.setRetention("l2", &solaris.Retention{MaxRecords: 1})
+
+	// only the logs with their own policy are processed, if there is no default one
+	rs := &testRetentionStorage{RetentionStorage: lms}
+	r := NewRetainer(RetainerConfig{BatchSize: 1})
+	r.LMStorage = lms
+	r.RStorage = rs
+	n, err := r.Apply(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, n)
+	assert.Equal(t, []string{"l2"}, rs.returned)
+
+	rs.returned = nil
+	r = NewRetainer(RetainerConfig{Default: &solaris.Retention{MaxAgeSeconds: 3600}, BatchSize: 1})
+	r.LMStorage = lms
+	r.RStorage = rs
+	_, err = r.Apply(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"l1", "l2", "l3"}, rs.returned)
+}
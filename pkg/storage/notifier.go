@@ -0,0 +1,119 @@
+// Copyright 2024 The Solaris Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"sync"
+)
+
+type (
+	// Notifier is an in-process notification mechanism about the logs changes. The Log implementation
+	// calls Notify every time new records are committed into a log, and the parties interested in the
+	// log changes (tailing readers, for instance) receive the notifications via a Subscription.
+	//
+	// The notifications are coalesced: the Subscription keeps the set of changed logs and a signal
+	// channel with the capacity 1, so Notify never blocks the append path regardless of how slow the
+	// subscriber is. The subscriber is supposed to read the changes itself at its own pace.
+	Notifier struct {
+		lock sync.Mutex
+		subs map[string]map[*Subscription]struct{}
+	}
+
+	// Subscription allows to receive notifications about the changes of a set of logs.
+	// Subscription must be closed when it is not needed anymore.
+	Subscription struct {
+		n       *Notifier
+		logIDs  []string
+		ch      chan struct{}
+		lock    sync.Mutex
+		changed map[string]struct{}
+	}
+)
+
+// NewNotifier creates the new Notifier
+func NewNotifier() *Notifier {
+	return &Notifier{subs: make(map[string]map[*Subscription]struct{})}
+}
+
+// Notify notifies the subscribers of the log logID that the log has been changed.
+// The function never blocks.
+func (n *Notifier) Notify(logID string) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	for s := range n.subs[logID] {
+		s.notify(logID)
+	}
+}
+
+// Subscribe creates the new Subscription for the logs changes. All the logIDs are considered
+// changed right after the call, so the subscriber receives the first signal immediately.
+func (n *Notifier) Subscribe(logIDs []string) *Subscription {
+	s := &Subscription{n: n, logIDs: logIDs, ch: make(chan struct{}, 1), changed: make(map[string]struct{}, len(logIDs))}
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	for _, lid := range logIDs {
+		m, ok := n.subs[lid]
+		if !ok {
+			m = make(map[*Subscription]struct{})
+			n.subs[lid] = m
+		}
+		m[s] = struct{}{}
+		s.notify(lid)
+	}
+	return s
+}
+
+// C returns the channel which is signaled when one or more logs of the subscription are changed.
+// The changed logs are returned by the Changed() function.
+func (s *Subscription) C() <-chan struct{} {
+	return s.ch
+}
+
+// Changed returns the IDs of the logs changed since the previous call and resets the changed set
+func (s *Subscription) Changed() []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	res := make([]string, 0, len(s.changed))
+	for lid := range s.changed {
+		res = append(res, lid)
+		delete(s.changed, lid)
+	}
+	return res
+}
+
+// Close unsubscribes the subscription, so no notifications will be received anymore.
+// The function is idempotent.
+func (s *Subscription) Close() {
+	s.n.lock.Lock()
+	defer s.n.lock.Unlock()
+	for _, lid := range s.logIDs {
+		if m, ok := s.n.subs[lid]; ok {
+			delete(m, s)
+			if len(m) == 0 {
+				delete(s.n.subs, lid)
+			}
+		}
+	}
+}
+
+func (s *Subscription) notify(logID string) {
+	s.lock.Lock()
+	s.changed[logID] = struct{}{}
+	s.lock.Unlock()
+	select {
+	case s.ch <- struct{}{}:
+	default:
+	}
+}
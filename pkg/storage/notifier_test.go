@@ -0,0 +1,98 @@
+// Copyright 2024 The Solaris Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"github.com/stretchr/testify/assert"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestNotifier_Subscribe(t *testing.T) {
+	n := NewNotifier()
+	s := n.Subscribe([]string{"l1", "l2"})
+	defer s.Close()
+
+	// all the logs are changed right after the subscription
+	assertSignaled(t, s, true)
+	assertChanged(t, s, []string{"l1", "l2"})
+	assertSignaled(t, s, false)
+
+	n.Notify("l3")
+	assertSignaled(t, s, false)
+
+	// the notifications are coalesced
+	n.Notify("l2")
+	n.Notify("l2")
+	n.Notify("l1")
+	assertSignaled(t, s, true)
+	assertSignaled(t, s, false)
+	assertChanged(t, s, []string{"l1", "l2"})
+	assertChanged(t, s, []string{})
+}
+
+func TestNotifier_Close(t *testing.T) {
+	n := NewNotifier()
+	s1 := n.Subscribe([]string{"l1", "l2"})
+	s2 := n.Subscribe([]string{"l2"})
+	assertChanged(t, s1, []string{"l1", "l2"})
+	assertChanged(t, s2, []string{"l2"})
+
+	s1.Close()
+	s1.Close()
+	assert.Equal(t, 1, len(n.subs))
+	n.Notify("l1")
+	n.Notify("l2")
+	assertChanged(t, s1, []string{})
+	assertChanged(t, s2, []string{"l2"})
+
+	s2.Close()
+	assert.Equal(t, 0, len(n.subs))
+}
+
+func TestNotifier_Wait(t *testing.T) {
+	n := NewNotifier()
+	s := n.Subscribe([]string{"l1"})
+	defer s.Close()
+	<-s.C()
+	s.Changed()
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		n.Notify("l1")
+	}()
+	select {
+	case <-s.C():
+	case <-time.After(time.Second):
+		t.Fatal("no notification received")
+	}
+	assertChanged(t, s, []string{"l1"})
+}
+
+func assertSignaled(t *testing.T, s *Subscription, expected bool) {
+	select {
+	case <-s.C():
+		assert.True(t, expected)
+	default:
+		assert.False(t, expected)
+	}
+}
+
+func assertChanged(t *testing.T, s *Subscription, expected []string) {
+	lids := s.Changed()
+	sort.Strings(lids)
+	assert.Equal(t, expected, lids)
+}
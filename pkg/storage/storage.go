@@ -29,14 +29,26 @@ type (
 		GetLogByID(ctx context.Context, id string) (*solaris.Log, error)
 		// UpdateLog update the Log object information. The Log is matched by the log ID
 		UpdateLog(ctx context.Context, log *solaris.Log) (*solaris.Log, error)
+		// PatchLogTags sets and removes the log tags by the request, the other log tags are not changed.
+		// It returns errors.ErrConflict if the request version is provided, but it is not the log current one.
+		PatchLogTags(ctx context.Context, request PatchLogTagsRequest) (*solaris.Log, error)
+		// BulkPatchLogTags applies the tags patch to all the logs matching the request condition
+		BulkPatchLogTags(ctx context.Context, request BulkPatchLogTagsRequest) (*solaris.BulkPatchLogTagsResult, error)
 		// QueryLogs returns the list of Log objects matched to the query request
 		QueryLogs(ctx context.Context, qr QueryLogsRequest) (*solaris.QueryLogsResult, error)
+		// GetTagFacets returns the distinct tag names, or the distinct values of the request tag, of the logs
+		// matching the request condition with the number of logs for every name (value)
+		GetTagFacets(ctx context.Context, request TagFacetsRequest) (*solaris.GetTagFacetsResult, error)
 		// DeleteLogs allows to either mark or delete logs permanently
 		DeleteLogs(ctx context.Context, request DeleteLogsRequest) (*solaris.DeleteLogsResult, error)
+		// UndeleteLogs restores the logs marked for deletion. The logs, which are not marked
+		// for deletion or are being purged already, are skipped.
+		UndeleteLogs(ctx context.Context, request UndeleteLogsRequest) (*solaris.UndeleteLogsResult, error)
 	}
 
 	// QueryLogsRequest is used for selecting list of known logs
 	QueryLogsRequest struct {
+		// Condition is the logs filter, the empty value selects all the logs
 		Condition string
 		// IDs is the list of Log IDs should be selected. If the value is not empty, the Condition field is disregarded
 		IDs []string
@@ -44,6 +56,45 @@ type (
 		Deleted bool
 		Page    string
 		Limit   int64
+		// SortBy defines the order of the logs, it is applied for the Condition queries only
+		SortBy solaris.LogsSortBy
+		// Descending specifies that the logs should be sorted in the descending order
+		Descending bool
+		// SkipTotal allows not to count the total number of the logs matching the Condition, which
+		// requires all the matching logs to be checked
+		SkipTotal bool
+	}
+
+	// TagFacetsRequest specifies the GetTagFacets parameters
+	TagFacetsRequest struct {
+		// Condition is the logs filter, the empty value selects all the logs
+		Condition string
+		// Tag is the tag name the values are counted for. If it is empty, the tag names are counted
+		Tag string
+		// Limit is the maximum number of facets returned, the value 0 means no limit
+		Limit int64
+	}
+
+	// PatchLogTagsRequest specifies the PatchLogTags parameters
+	PatchLogTagsRequest struct {
+		// ID is the log ID
+		ID string
+		// Set contains the tags to be added or changed
+		Set map[string]string
+		// Remove contains the tag names to be removed
+		Remove []string
+		// Version is the expected log version, the value 0 means any version
+		Version int64
+	}
+
+	// BulkPatchLogTagsRequest specifies the BulkPatchLogTags parameters
+	BulkPatchLogTagsRequest struct {
+		// Condition selects the logs to be patched
+		Condition string
+		// Set contains the tags to be added or changed
+		Set map[string]string
+		// Remove contains the tag names to be removed
+		Remove []string
 	}
 
 	// DeleteLogsRequest specifies the DeleteLogs parameters
@@ -55,13 +106,31 @@ type (
 		MarkOnly bool
 	}
 
+	// UndeleteLogsRequest specifies the UndeleteLogs parameters
+	UndeleteLogsRequest struct {
+		// Condition selects the logs among the deleted ones
+		Condition string
+		// IDs is the list of Log IDs should be restored. If the value is not empty, the Condition field is disregarded
+		IDs []string
+	}
+
 	// Log interface exposes an API for working with a Log records.
 	Log interface {
 		// AppendRecords allows to insert records into the log by its ID
 		AppendRecords(ctx context.Context, request *solaris.AppendRecordsRequest) (*solaris.AppendRecordsResult, error)
+		// AppendRecordsBatch allows to insert records into several logs atomically, so either all the records are
+		// added, or none of them. The results are returned in the order of the requests
+		AppendRecordsBatch(ctx context.Context, requests []*solaris.AppendRecordsRequest) ([]*solaris.AppendRecordsResult, error)
 		// QueryRecords allows to retrieve records by the request. The function returns the selected records and the flag,
 		// that more records potentially available for the read
 		QueryRecords(ctx context.Context, request QueryRecordsRequest) ([]*solaris.Record, bool, error)
+		// CountRecords returns the number of records matching the request. The request Limit is disregarded.
+		CountRecords(ctx context.Context, request QueryRecordsRequest) (int64, error)
+		// TruncateLog removes the log records before the record ID or the time provided. The function returns
+		// the number of records removed
+		TruncateLog(ctx context.Context, request *solaris.TruncateLogRequest) (*solaris.TruncateLogResult, error)
+		// GetLogStats returns the log records statistics
+		GetLogStats(ctx context.Context, logID string) (*solaris.LogStats, error)
 	}
 
 	QueryRecordsRequest struct {